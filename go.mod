@@ -5,4 +5,6 @@ go 1.23
 require (
 	github.com/hashicorp/go-cleanhttp v0.5.2
 	github.com/matryer/is v1.4.1
+	golang.org/x/time v0.9.0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,59 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// accountAPIPath is the HTTP path for the account API.
+const accountAPIPath = "account"
+
+// Account represents the NextDNS account owning the API key.
+type Account struct {
+	Email    string `json:"email"`
+	Plan     string `json:"plan,omitempty"`     // Subscription tier, e.g. "free" or "pro"
+	Profiles int    `json:"profiles,omitempty"` // Number of profiles of the account
+}
+
+// AccountService is an interface for communicating with the NextDNS account API endpoint.
+type AccountService interface {
+	Get(context.Context) (*Account, error)
+}
+
+// accountResponse represents the account response.
+type accountResponse struct {
+	Account *Account `json:"data"`
+}
+
+// accountService represents the NextDNS account service.
+type accountService struct {
+	client *serviceClient
+}
+
+var _ AccountService = &accountService{}
+
+// NewAccountService returns a new NextDNS account service.
+// nolint: revive
+func NewAccountService(client *Client) *accountService {
+	return &accountService{
+		client: newServiceClient(client, ServiceAccount),
+	}
+}
+
+// Get returns the account owning the API key. It's useful to check which account the API key
+// gives access to before making requests on its profiles.
+func (s *accountService) Get(ctx context.Context) (*Account, error) {
+	req, err := s.client.newRequest(http.MethodGet, accountAPIPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to get the account: %w", err)
+	}
+
+	response := accountResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error making a request to get the account: %w", err)
+	}
+
+	return response.Account, nil
+}
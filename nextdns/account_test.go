@@ -0,0 +1,31 @@
+package nextdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestAccountGet(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/account")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": {"email": "admin@example.com", "plan": "pro", "profiles": 3}}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	account, err := client.Account.Get(context.Background())
+	c.NoErr(err)
+	c.Equal(account, &Account{Email: "admin@example.com", Plan: "pro", Profiles: 3})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 // allowlistAPIPath is the HTTP path for the allowlist API.
@@ -12,6 +13,7 @@ const allowlistAPIPath = "allowlist"
 // Allowlist represents the allow list of a profile.
 type Allowlist struct {
 	ID     string `json:"id,omitempty"`
+	Name   string `json:"name,omitempty"` // Optional user-set label of the entry.
 	Active bool   `json:"active"`
 }
 
@@ -53,16 +55,18 @@ type AllowlistService interface {
 	Update(context.Context, *UpdateAllowlistRequest) error
 	Delete(context.Context, *DeleteAllowlistRequest) error
 	Add(context.Context, *AddAllowlistRequest) error
+	Search(ctx context.Context, profileID string, query string) ([]*Allowlist, error)
 }
 
 // allowlistResponse represents the allowlist response.
 type allowlistResponse struct {
 	Allowlist []*Allowlist `json:"data"`
+	listMeta
 }
 
 // privacyService represents the NextDNS allowlist service.
 type allowlistService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ AllowlistService = &allowlistService{}
@@ -71,7 +75,7 @@ var _ AllowlistService = &allowlistService{}
 // nolint: revive
 func NewAllowlistService(client *Client) *allowlistService {
 	return &allowlistService{
-		client: client,
+		client: newServiceClient(client, ServiceAllowlist),
 	}
 }
 
@@ -85,7 +89,11 @@ func (s *allowlistService) Create(ctx context.Context, request *CreateAllowlistR
 
 	err = s.client.do(ctx, req, nil)
 	if err != nil {
-		return fmt.Errorf("error making a request to create an allow list: %w", err)
+		ids := make([]string, len(request.Allowlist))
+		for i, entry := range request.Allowlist {
+			ids[i] = entry.ID
+		}
+		return fmt.Errorf("error making a request to create an allow list: %w", newBulkError(err, ids))
 	}
 
 	return nil
@@ -147,7 +155,7 @@ func (s *allowlistService) Add(ctx context.Context, request *AddAllowlistRequest
 		ID     string `json:"id"`
 		Active *bool  `json:"active,omitempty"`
 	}{
-		ID:     request.ID,
+		ID:     NormalizeDomain(request.ID),
 		Active: request.Active,
 	}
 	req, err := s.client.newRequest(http.MethodPost, path, body)
@@ -163,7 +171,28 @@ func (s *allowlistService) Add(ctx context.Context, request *AddAllowlistRequest
 	return nil
 }
 
+// Search returns the allow list entries whose ID contains the query, ignoring case.
+// The NextDNS API doesn't support searching the allow list, so the entries are filtered client-side
+// after listing the whole allow list.
+func (s *allowlistService) Search(ctx context.Context, profileID string, query string) ([]*Allowlist, error) {
+	list, err := s.List(ctx, &ListAllowlistRequest{ProfileID: profileID})
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	results := make([]*Allowlist, 0)
+	for _, entry := range list {
+		if strings.Contains(strings.ToLower(entry.ID), query) {
+			results = append(results, entry)
+		}
+	}
+
+	return results, nil
+}
+
 // allowlistIDAPIPath returns the HTTP path for the allowlist API.
+// The ID is path-escaped, so wildcard entries like "*.ads.com" are safely encoded.
 func allowlistIDAPIPath(id string) string {
-	return fmt.Sprintf("%s/%s", allowlistAPIPath, id)
+	return fmt.Sprintf("%s/%s", allowlistAPIPath, pathSegment(id))
 }
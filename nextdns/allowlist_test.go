@@ -2,6 +2,8 @@ package nextdns
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -76,11 +78,17 @@ func TestAllowlistGet(t *testing.T) {
 func TestAllowlistUpdate(t *testing.T) {
 	c := is.New(t)
 
-	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusNoContent)
-		_, err := w.Write([]byte(""))
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PATCH")
+		c.Equal(r.URL.Path, "/profiles/abc123/allowlist/duckduckgo.com")
+
+		body, err := io.ReadAll(r.Body)
 		c.NoErr(err)
+		c.Equal(string(body), `{"active":true}`+"\n")
+
+		w.WriteHeader(http.StatusNoContent)
 	}))
+	defer ts.Close()
 
 	client, err := New(WithBaseURL(ts.URL))
 	c.NoErr(err)
@@ -151,3 +159,126 @@ func TestAllowlistAdd(t *testing.T) {
 
 	c.NoErr(err)
 }
+
+func TestAllowlistAddOnlyID(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "POST")
+		c.Equal(r.URL.Path, "/profiles/abc123/allowlist")
+
+		body, err := io.ReadAll(r.Body)
+		c.NoErr(err)
+		c.Equal(string(body), `{"id":"example.com"}`+"\n")
+
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write([]byte(`{"data": {"id": "example.com", "active": true}}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	err = client.Allowlist.Add(context.Background(), &AddAllowlistRequest{
+		ProfileID: "abc123",
+		ID:        "example.com",
+	})
+
+	c.NoErr(err)
+}
+
+func TestAllowlistAddWildcard(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "POST")
+		c.Equal(r.URL.Path, "/profiles/abc123/allowlist")
+
+		body, err := io.ReadAll(r.Body)
+		c.NoErr(err)
+		c.Equal(string(body), "{\"id\":\"*.ads.com\"}\n")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{"data": {"id": "*.ads.com"}}`
+		_, err = w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	err = client.Allowlist.Add(ctx, &AddAllowlistRequest{
+		ProfileID: "abc123",
+		ID:        " *.Ads.com. ",
+	})
+
+	c.NoErr(err)
+}
+
+func TestAllowlistDeleteWildcardPathEncoding(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "DELETE")
+		c.Equal(r.URL.EscapedPath(), "/profiles/abc123/allowlist/%2A.ads.com")
+		c.Equal(r.URL.Path, "/profiles/abc123/allowlist/*.ads.com")
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	err = client.Allowlist.Delete(ctx, &DeleteAllowlistRequest{
+		ProfileID: "abc123",
+		ID:        "*.ads.com",
+	})
+
+	c.NoErr(err)
+}
+
+func TestAllowlistSearch(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/allowlist")
+
+		w.WriteHeader(http.StatusOK)
+		out := `{"data":[{"id":"ads.google.com","active":true},{"id":"apple.com","active":false},{"id":"*.googlesyndication.com","active":true}]}`
+		_, err := w.Write([]byte(out))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	results, err := client.Allowlist.Search(ctx, "abc123", "Google")
+
+	c.NoErr(err)
+	c.Equal(results, []*Allowlist{
+		{ID: "ads.google.com", Active: true},
+		{ID: "*.googlesyndication.com", Active: true},
+	})
+}
+
+func TestAllowlistUnmarshalName(t *testing.T) {
+	c := is.New(t)
+
+	var response allowlistResponse
+	err := json.Unmarshal([]byte(`{"data":[{"id":"ads.com","name":"Ad network","active":true},{"id":"apple.com","active":false}]}`), &response)
+	c.NoErr(err)
+	c.Equal(response.Allowlist[0].Name, "Ad network")
+	c.Equal(response.Allowlist[1].Name, "")
+
+	out, err := json.Marshal(response.Allowlist)
+	c.NoErr(err)
+	c.Equal(string(out), `[{"id":"ads.com","name":"Ad network","active":true},{"id":"apple.com","active":false}]`)
+}
@@ -1,15 +1,22 @@
 package nextdns
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
-	"strconv"
+	"slices"
+	"strings"
+	"time"
 )
 
 const analyticsAPIPath = "analytics"
 
+// analyticsMultiConcurrency is the maximum number of concurrent requests made by the multi-profile methods.
+const analyticsMultiConcurrency = 4
+
 // AnalyticsOptions contains common parameters for all analytics endpoints.
 type AnalyticsOptions struct {
 	From   string // Date filter (ISO 8601, Unix timestamp, or relative like "-7d")
@@ -17,8 +24,20 @@ type AnalyticsOptions struct {
 	Limit  int    // Results per page (1-500, default 10)
 	Cursor string // Pagination cursor
 	Device string // Filter by device ID
+
+	// MinQueries drops the entries with fewer queries from the responses. The API doesn't support
+	// this filter, so it's applied client-side to each page: a page can then have fewer entries
+	// than Limit. It's ignored by the time series.
+	MinQueries int64
 }
 
+// Analytics status filters, restricting the analytics to the queries resolved with the status.
+const (
+	AnalyticsStatusDefault = "default"
+	AnalyticsStatusBlocked = "blocked"
+	AnalyticsStatusAllowed = "allowed"
+)
+
 // AnalyticsTimeSeriesOptions extends AnalyticsOptions with time series parameters.
 type AnalyticsTimeSeriesOptions struct {
 	AnalyticsOptions
@@ -26,20 +45,92 @@ type AnalyticsTimeSeriesOptions struct {
 	Alignment string // "start", "end", or "clock"
 	Timezone  string // IANA timezone (e.g., "America/New_York")
 	Partials  string // "none", "start", "end", "all"
+
+	// SkipTimezoneCheck disables the client-side check that Timezone is only set along with the
+	// "clock" alignment, the only one the timezone applies to.
+	SkipTimezoneCheck bool
 }
 
-// AnalyticsEntry represents a single item in analytics responses.
+// AnalyticsEntry represents a single item in analytics responses. A null query count, returned
+// for partial windows, is decoded as 0.
 type AnalyticsEntry struct {
 	ID      string `json:"id"`
 	Name    string `json:"name,omitempty"`
-	Queries int    `json:"queries"`
+	Queries int64  `json:"queries"`
 }
 
-// AnalyticsTimeSeriesEntry has queries as an array for each time window.
-type AnalyticsTimeSeriesEntry struct {
+// queryCount returns the number of queries of the entry.
+func (e *AnalyticsEntry) queryCount() int64 {
+	return e.Queries
+}
+
+// AnalyticsDeviceEntry represents a single device in the analytics devices response.
+type AnalyticsDeviceEntry struct {
 	ID      string `json:"id"`
 	Name    string `json:"name,omitempty"`
-	Queries []int  `json:"queries"`
+	Model   string `json:"model,omitempty"`
+	LocalIP string `json:"localIp,omitempty"`
+	Queries int64  `json:"queries"`
+}
+
+// queryCount returns the number of queries of the device.
+func (e *AnalyticsDeviceEntry) queryCount() int64 {
+	return e.Queries
+}
+
+// AnalyticsIPEntry represents a single IP address in the analytics IPs response.
+type AnalyticsIPEntry struct {
+	AnalyticsEntry
+	Network    string // Name of the network (ISP) the IP belongs to.
+	ASN        int    // Autonomous system number of the network the IP belongs to.
+	GeoCountry string // Country the IP is located in.
+	GeoCity    string // City the IP is located in.
+}
+
+// UnmarshalJSON decodes an IP entry, flattening the nested network and geo objects returned by the API.
+func (e *AnalyticsIPEntry) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ID      string `json:"id"`
+		IP      string `json:"ip"`
+		Name    string `json:"name"`
+		Queries int64  `json:"queries"`
+		Network struct {
+			ISP string `json:"isp"`
+			ASN int    `json:"asn"`
+		} `json:"network"`
+		Geo struct {
+			Country string `json:"country"`
+			City    string `json:"city"`
+		} `json:"geo"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	id := raw.ID
+	if id == "" {
+		id = raw.IP
+	}
+	*e = AnalyticsIPEntry{
+		AnalyticsEntry: AnalyticsEntry{
+			ID:      id,
+			Name:    raw.Name,
+			Queries: raw.Queries,
+		},
+		Network:    raw.Network.ISP,
+		ASN:        raw.Network.ASN,
+		GeoCountry: raw.Geo.Country,
+		GeoCity:    raw.Geo.City,
+	}
+	return nil
+}
+
+// AnalyticsTimeSeriesEntry has queries as an array for each time window. A null element of the
+// array, returned for partial windows, is decoded as 0.
+type AnalyticsTimeSeriesEntry struct {
+	ID      string  `json:"id"`
+	Name    string  `json:"name,omitempty"`
+	Queries []int64 `json:"queries"`
 }
 
 // AnalyticsPagination contains cursor for pagination.
@@ -53,6 +144,20 @@ type AnalyticsSeriesInfo struct {
 	Interval int      `json:"interval"`
 }
 
+// UTCTimes parses the series window times and normalizes them to UTC, regardless of
+// the timezone requested for the series.
+func (s AnalyticsSeriesInfo) UTCTimes() ([]time.Time, error) {
+	times := make([]time.Time, len(s.Times))
+	for i, raw := range s.Times {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing series time %q: %w", raw, err)
+		}
+		times[i] = t.UTC()
+	}
+	return times, nil
+}
+
 // analyticsResponse is the internal response wrapper for standard analytics.
 type analyticsResponse struct {
 	Data []*AnalyticsEntry `json:"data"`
@@ -61,6 +166,22 @@ type analyticsResponse struct {
 	} `json:"meta"`
 }
 
+// analyticsDevicesResponse is the internal response wrapper for the devices analytics.
+type analyticsDevicesResponse struct {
+	Data []*AnalyticsDeviceEntry `json:"data"`
+	Meta struct {
+		Pagination AnalyticsPagination `json:"pagination"`
+	} `json:"meta"`
+}
+
+// analyticsIPsResponse is the internal response wrapper for the IPs analytics.
+type analyticsIPsResponse struct {
+	Data []*AnalyticsIPEntry `json:"data"`
+	Meta struct {
+		Pagination AnalyticsPagination `json:"pagination"`
+	} `json:"meta"`
+}
+
 // analyticsTimeSeriesResponse is the internal response wrapper for time series analytics.
 type analyticsTimeSeriesResponse struct {
 	Data []*AnalyticsTimeSeriesEntry `json:"data"`
@@ -78,6 +199,37 @@ type AnalyticsResponse struct {
 	Pagination AnalyticsPagination
 }
 
+// Percentages returns the share of the total queries of each entry, by ID, as a percentage
+// between 0 and 100. It returns an empty map when there are no queries.
+func (r *AnalyticsResponse) Percentages() map[string]float64 {
+	percentages := make(map[string]float64, len(r.Data))
+
+	var total int64
+	for _, entry := range r.Data {
+		total += entry.Queries
+	}
+	if total == 0 {
+		return percentages
+	}
+
+	for _, entry := range r.Data {
+		percentages[entry.ID] = float64(entry.Queries) * 100 / float64(total)
+	}
+	return percentages
+}
+
+// AnalyticsDevicesResponse contains the devices analytics data with pagination info.
+type AnalyticsDevicesResponse struct {
+	Data       []*AnalyticsDeviceEntry
+	Pagination AnalyticsPagination
+}
+
+// AnalyticsIPsResponse contains the IPs analytics data with pagination info.
+type AnalyticsIPsResponse struct {
+	Data       []*AnalyticsIPEntry
+	Pagination AnalyticsPagination
+}
+
 // AnalyticsTimeSeriesResponse contains time series analytics data.
 type AnalyticsTimeSeriesResponse struct {
 	Data       []*AnalyticsTimeSeriesEntry
@@ -91,12 +243,14 @@ type AnalyticsTimeSeriesResponse struct {
 type GetAnalyticsRequest struct {
 	ProfileID string
 	Options   *AnalyticsOptions
+	Status    string // Filter: AnalyticsStatusDefault, AnalyticsStatusBlocked or AnalyticsStatusAllowed
 }
 
 // GetAnalyticsTimeSeriesRequest is used for status and devices time series.
 type GetAnalyticsTimeSeriesRequest struct {
 	ProfileID string
 	Options   *AnalyticsTimeSeriesOptions
+	Status    string // Filter: AnalyticsStatusDefault, AnalyticsStatusBlocked or AnalyticsStatusAllowed
 }
 
 // GetAnalyticsDomainsRequest includes domain-specific filters.
@@ -126,7 +280,14 @@ type GetAnalyticsDestinationsRequest struct {
 type GetAnalyticsDestinationsTimeSeriesRequest struct {
 	ProfileID string
 	Options   *AnalyticsTimeSeriesOptions
-	Type      string
+	Type      string // Required: "countries" or "gafam"
+}
+
+// DeviceGroup is a named set of devices of a profile, such as "Kids' devices". The API has no
+// such concept: the group is only held client-side, to merge the analytics of its devices.
+type DeviceGroup struct {
+	Name      string
+	DeviceIDs []string
 }
 
 // AnalyticsService provides access to NextDNS analytics data.
@@ -134,22 +295,51 @@ type AnalyticsService interface {
 	// Status returns query counts by resolution status (default, blocked, allowed).
 	GetStatus(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsResponse, error)
 	GetStatusSeries(ctx context.Context, request *GetAnalyticsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error)
+	GetStatusMulti(ctx context.Context, profileIDs []string, opts *AnalyticsOptions) (map[string]*AnalyticsResponse, error)
+	GetStatusForGroup(ctx context.Context, profileID string, group DeviceGroup, opts *AnalyticsOptions) (*AnalyticsResponse, error)
 
 	// Domains returns top queried domains.
 	GetDomains(ctx context.Context, request *GetAnalyticsDomainsRequest) (*AnalyticsResponse, error)
 	GetDomainsSeries(ctx context.Context, request *GetAnalyticsDomainsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error)
+	// IterateDomains returns an iterator over the top queried domains; the context is passed to Next.
+	IterateDomains(request *GetAnalyticsDomainsRequest) *AnalyticsIterator
+	GetDomainsByDevice(ctx context.Context, profileID string, deviceIDs []string, opts *AnalyticsOptions) (map[string]*AnalyticsResponse, error)
 
 	// Devices returns connected devices and query distribution.
-	GetDevices(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsResponse, error)
+	GetDevices(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsDevicesResponse, error)
 	GetDevicesSeries(ctx context.Context, request *GetAnalyticsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error)
 
+	// IPs returns the client IP addresses with their network and location.
+	GetIPs(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsIPsResponse, error)
+	GetIPsSeries(ctx context.Context, request *GetAnalyticsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error)
+
+	// DNSSEC returns queries by DNSSEC validation (validated, not validated).
+	GetDNSSEC(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsResponse, error)
+	GetDNSSECSeries(ctx context.Context, request *GetAnalyticsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error)
+
+	// Encryption returns queries by encryption (encrypted, unencrypted).
+	GetEncryption(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsResponse, error)
+	GetEncryptionSeries(ctx context.Context, request *GetAnalyticsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error)
+
+	// IPVersions returns queries by IP version (IPv4, IPv6).
+	GetIPVersions(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsResponse, error)
+	GetIPVersionsSeries(ctx context.Context, request *GetAnalyticsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error)
+
+	// Protocols returns queries by DNS protocol (DNS-over-HTTPS, DNS-over-TLS, UDP, ...).
+	GetProtocols(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsResponse, error)
+	GetProtocolsSeries(ctx context.Context, request *GetAnalyticsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error)
+
+	// Reasons returns queries by block reason (blocklists, natives, parental control, ...).
+	GetReasons(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsResponse, error)
+	GetReasonsSeries(ctx context.Context, request *GetAnalyticsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error)
+
 	// Destinations returns queries by country or GAFAM company.
 	GetDestinations(ctx context.Context, request *GetAnalyticsDestinationsRequest) (*AnalyticsResponse, error)
 	GetDestinationsSeries(ctx context.Context, request *GetAnalyticsDestinationsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error)
 }
 
 type analyticsService struct {
-	client *Client
+	client *serviceClient
 }
 
 // Compile-time check that analyticsService implements AnalyticsService.
@@ -159,32 +349,38 @@ var _ AnalyticsService = &analyticsService{}
 // nolint: revive
 func NewAnalyticsService(client *Client) *analyticsService {
 	return &analyticsService{
-		client: client,
+		client: newServiceClient(client, ServiceAnalytics),
 	}
 }
 
+// filterMinQueries drops the entries with fewer queries than the MinQueries option.
+func filterMinQueries[T interface{ queryCount() int64 }](entries []T, opts *AnalyticsOptions) []T {
+	if opts == nil || opts.MinQueries <= 0 {
+		return entries
+	}
+
+	filtered := make([]T, 0, len(entries))
+	for _, entry := range entries {
+		if entry.queryCount() >= opts.MinQueries {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
 // buildAnalyticsQuery converts AnalyticsOptions to url.Values.
 func buildAnalyticsQuery(opts *AnalyticsOptions) url.Values {
-	query := url.Values{}
+	query := newQueryBuilder()
 	if opts == nil {
-		return query
-	}
-	if opts.From != "" {
-		query.Set("from", opts.From)
-	}
-	if opts.To != "" {
-		query.Set("to", opts.To)
-	}
-	if opts.Limit > 0 {
-		query.Set("limit", strconv.Itoa(opts.Limit))
-	}
-	if opts.Cursor != "" {
-		query.Set("cursor", opts.Cursor)
+		return query.Values()
 	}
-	if opts.Device != "" {
-		query.Set("device", opts.Device)
-	}
-	return query
+	return query.
+		SetString("from", opts.From).
+		SetString("to", opts.To).
+		SetInt("limit", opts.Limit).
+		SetString("cursor", opts.Cursor).
+		SetString("device", opts.Device).
+		Values()
 }
 
 // buildTimeSeriesQuery adds time series parameters to the query.
@@ -192,30 +388,91 @@ func buildTimeSeriesQuery(opts *AnalyticsTimeSeriesOptions) url.Values {
 	if opts == nil {
 		return url.Values{}
 	}
-	query := buildAnalyticsQuery(&opts.AnalyticsOptions)
-	if opts.Interval != "" {
-		query.Set("interval", opts.Interval)
+	return queryBuilder(buildAnalyticsQuery(&opts.AnalyticsOptions)).
+		SetString("interval", opts.Interval).
+		SetString("alignment", opts.Alignment).
+		SetString("timezone", opts.Timezone).
+		SetString("partials", opts.Partials).
+		Values()
+}
+
+// buildAnalyticsStatusQuery converts AnalyticsOptions and a status filter to url.Values.
+func buildAnalyticsStatusQuery(opts *AnalyticsOptions, status string) (url.Values, error) {
+	if err := validateAnalyticsStatus(status); err != nil {
+		return nil, err
 	}
-	if opts.Alignment != "" {
-		query.Set("alignment", opts.Alignment)
+	return queryBuilder(buildAnalyticsQuery(opts)).SetString("status", status).Values(), nil
+}
+
+// buildTimeSeriesStatusQuery converts AnalyticsTimeSeriesOptions and a status filter to url.Values.
+func buildTimeSeriesStatusQuery(opts *AnalyticsTimeSeriesOptions, status string) (url.Values, error) {
+	if err := validateAnalyticsStatus(status); err != nil {
+		return nil, err
 	}
-	if opts.Timezone != "" {
-		query.Set("timezone", opts.Timezone)
+	if err := validateTimeSeriesTimezone(opts); err != nil {
+		return nil, err
 	}
-	if opts.Partials != "" {
-		query.Set("partials", opts.Partials)
+	return queryBuilder(buildTimeSeriesQuery(opts)).SetString("status", status).Values(), nil
+}
+
+// validateAnalyticsStatus checks that the status filter is empty or set to a supported value.
+func validateAnalyticsStatus(status string) error {
+	switch status {
+	case "", AnalyticsStatusDefault, AnalyticsStatusBlocked, AnalyticsStatusAllowed:
+		return nil
+	default:
+		return &Error{
+			Type:    ErrorTypeRequest,
+			Message: fmt.Sprintf("invalid analytics status %q: must be %q, %q or %q", status, AnalyticsStatusDefault, AnalyticsStatusBlocked, AnalyticsStatusAllowed),
+		}
+	}
+}
+
+// validateDestinationsType checks that the destinations type is set to a supported value.
+func validateDestinationsType(destinationsType string) error {
+	switch destinationsType {
+	case "countries", "gafam":
+		return nil
+	default:
+		return fmt.Errorf("%w: got %q", ErrInvalidDestinationsType, destinationsType)
+	}
+}
+
+// validateTimeSeriesOptions checks the options of a time series request set the interval of the windows.
+func validateTimeSeriesOptions(opts *AnalyticsTimeSeriesOptions) error {
+	if opts == nil || opts.Interval == "" {
+		return &Error{
+			Type:    ErrorTypeRequest,
+			Message: "time series requests require AnalyticsTimeSeriesOptions with an interval",
+		}
+	}
+	return nil
+}
+
+// validateTimeSeriesTimezone checks the timezone of a time series request is only set with the
+// clock alignment: the windows aligned on their start or end ignore it. The check is skipped
+// when the options set SkipTimezoneCheck.
+func validateTimeSeriesTimezone(opts *AnalyticsTimeSeriesOptions) error {
+	if opts == nil || opts.SkipTimezoneCheck || opts.Timezone == "" || opts.Alignment == "clock" {
+		return nil
+	}
+	return &Error{
+		Type:    ErrorTypeRequest,
+		Message: fmt.Sprintf("the timezone %q requires the \"clock\" alignment, got %q: set Alignment to \"clock\" or SkipTimezoneCheck to send it anyway", opts.Timezone, opts.Alignment),
 	}
-	return query
 }
 
 func analyticsPath(profileID, endpoint string) string {
-	return fmt.Sprintf("%s/%s/%s/%s", profilesAPIPath, profileID, analyticsAPIPath, endpoint)
+	return fmt.Sprintf("%s/%s/%s", profileAPIPath(profileID), analyticsAPIPath, endpoint)
 }
 
 // GetStatus returns query counts by resolution status.
 func (s *analyticsService) GetStatus(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsResponse, error) {
 	path := analyticsPath(request.ProfileID, "status")
-	query := buildAnalyticsQuery(request.Options)
+	query, err := buildAnalyticsStatusQuery(request.Options, request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics status: %w", err)
+	}
 
 	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
 	if err != nil {
@@ -229,15 +486,22 @@ func (s *analyticsService) GetStatus(ctx context.Context, request *GetAnalyticsR
 	}
 
 	return &AnalyticsResponse{
-		Data:       response.Data,
+		Data:       filterMinQueries(response.Data, request.Options),
 		Pagination: response.Meta.Pagination,
 	}, nil
 }
 
 // GetStatusSeries returns query counts by resolution status as time series.
 func (s *analyticsService) GetStatusSeries(ctx context.Context, request *GetAnalyticsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error) {
+	if err := validateTimeSeriesOptions(request.Options); err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics status series: %w", err)
+	}
+
 	path := analyticsPath(request.ProfileID, "status;series")
-	query := buildTimeSeriesQuery(request.Options)
+	query, err := buildTimeSeriesStatusQuery(request.Options, request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics status series: %w", err)
+	}
 
 	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
 	if err != nil {
@@ -257,6 +521,87 @@ func (s *analyticsService) GetStatusSeries(ctx context.Context, request *GetAnal
 	}, nil
 }
 
+// GetStatusMulti returns query counts by resolution status for multiple profiles, keyed by profile ID.
+// The NextDNS API has no multi-profile analytics, so the profiles are queried concurrently. The
+// responses of the profiles that succeeded are always returned, alongside the joined errors of
+// the profiles that failed.
+func (s *analyticsService) GetStatusMulti(ctx context.Context, profileIDs []string, opts *AnalyticsOptions) (map[string]*AnalyticsResponse, error) {
+	results := make([]*AnalyticsResponse, len(profileIDs))
+	errs := forEachConcurrent(ctx, profileIDs, analyticsMultiConcurrency, func(i int, profileID string) error {
+		response, err := s.GetStatus(ctx, &GetAnalyticsRequest{
+			ProfileID: profileID,
+			Options:   opts,
+		})
+		results[i] = response
+		return err
+	})
+
+	responses := make(map[string]*AnalyticsResponse, len(profileIDs))
+	for i, response := range results {
+		if response != nil {
+			responses[profileIDs[i]] = response
+		}
+	}
+	return responses, joinIDErrors("profile", profileIDs, errs)
+}
+
+// GetStatusForGroup returns query counts by resolution status for the devices of a group, merged
+// into a single response sorted by query count. The devices are queried concurrently, once each,
+// the Device filter of the options being replaced by each device. The MinQueries filter applies
+// to the merged totals. As a partial total would be misleading, the joined errors of the devices
+// that failed are returned without a response.
+func (s *analyticsService) GetStatusForGroup(ctx context.Context, profileID string, group DeviceGroup, opts *AnalyticsOptions) (*AnalyticsResponse, error) {
+	deviceIDs := slices.Clone(group.DeviceIDs)
+	slices.Sort(deviceIDs)
+	deviceIDs = slices.Compact(deviceIDs)
+
+	results := make([]*AnalyticsResponse, len(deviceIDs))
+	errs := forEachConcurrent(ctx, deviceIDs, analyticsMultiConcurrency, func(i int, deviceID string) error {
+		deviceOpts := AnalyticsOptions{}
+		if opts != nil {
+			deviceOpts = *opts
+		}
+		deviceOpts.Device = deviceID
+		deviceOpts.Cursor = ""
+		deviceOpts.MinQueries = 0
+
+		response, err := s.GetStatus(ctx, &GetAnalyticsRequest{
+			ProfileID: profileID,
+			Options:   &deviceOpts,
+		})
+		results[i] = response
+		return err
+	})
+	if err := joinIDErrors("device", deviceIDs, errs); err != nil {
+		return nil, fmt.Errorf("error getting analytics status for device group %q: %w", group.Name, err)
+	}
+
+	entries := make(map[string]*AnalyticsEntry)
+	for _, response := range results {
+		for _, entry := range response.Data {
+			merged, ok := entries[entry.ID]
+			if !ok {
+				merged = &AnalyticsEntry{ID: entry.ID, Name: entry.Name}
+				entries[entry.ID] = merged
+			}
+			merged.Queries += entry.Queries
+		}
+	}
+
+	data := make([]*AnalyticsEntry, 0, len(entries))
+	for _, entry := range entries {
+		data = append(data, entry)
+	}
+	slices.SortFunc(data, func(a, b *AnalyticsEntry) int {
+		if c := cmp.Compare(b.Queries, a.Queries); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return &AnalyticsResponse{Data: filterMinQueries(data, opts)}, nil
+}
+
 // GetDomains returns top queried domains.
 func (s *analyticsService) GetDomains(ctx context.Context, request *GetAnalyticsDomainsRequest) (*AnalyticsResponse, error) {
 	path := analyticsPath(request.ProfileID, "domains")
@@ -280,13 +625,47 @@ func (s *analyticsService) GetDomains(ctx context.Context, request *GetAnalytics
 	}
 
 	return &AnalyticsResponse{
-		Data:       response.Data,
+		Data:       filterMinQueries(response.Data, request.Options),
 		Pagination: response.Meta.Pagination,
 	}, nil
 }
 
+// GetDomainsByDevice returns the top queried domains of multiple devices of a profile, keyed by
+// device ID. The devices are queried concurrently, the Device filter of the options being
+// replaced by each device. The responses of the devices that succeeded are always returned,
+// alongside the joined errors of the devices that failed.
+func (s *analyticsService) GetDomainsByDevice(ctx context.Context, profileID string, deviceIDs []string, opts *AnalyticsOptions) (map[string]*AnalyticsResponse, error) {
+	results := make([]*AnalyticsResponse, len(deviceIDs))
+	errs := forEachConcurrent(ctx, deviceIDs, analyticsMultiConcurrency, func(i int, deviceID string) error {
+		deviceOpts := AnalyticsOptions{}
+		if opts != nil {
+			deviceOpts = *opts
+		}
+		deviceOpts.Device = deviceID
+
+		response, err := s.GetDomains(ctx, &GetAnalyticsDomainsRequest{
+			ProfileID: profileID,
+			Options:   &deviceOpts,
+		})
+		results[i] = response
+		return err
+	})
+
+	responses := make(map[string]*AnalyticsResponse, len(deviceIDs))
+	for i, response := range results {
+		if response != nil {
+			responses[deviceIDs[i]] = response
+		}
+	}
+	return responses, joinIDErrors("device", deviceIDs, errs)
+}
+
 // GetDomainsSeries returns top queried domains as time series.
 func (s *analyticsService) GetDomainsSeries(ctx context.Context, request *GetAnalyticsDomainsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error) {
+	if err := validateTimeSeriesTimezone(request.Options); err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics domains series: %w", err)
+	}
+
 	path := analyticsPath(request.ProfileID, "domains;series")
 	query := buildTimeSeriesQuery(request.Options)
 	if request.Status != "" {
@@ -315,23 +694,26 @@ func (s *analyticsService) GetDomainsSeries(ctx context.Context, request *GetAna
 }
 
 // GetDevices returns connected devices and query distribution.
-func (s *analyticsService) GetDevices(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsResponse, error) {
+func (s *analyticsService) GetDevices(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsDevicesResponse, error) {
 	path := analyticsPath(request.ProfileID, "devices")
-	query := buildAnalyticsQuery(request.Options)
+	query, err := buildAnalyticsStatusQuery(request.Options, request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics devices: %w", err)
+	}
 
 	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error creating request to get analytics devices: %w", err)
 	}
 
-	response := analyticsResponse{}
+	response := analyticsDevicesResponse{}
 	err = s.client.do(ctx, req, &response)
 	if err != nil {
 		return nil, fmt.Errorf("error making request to get analytics devices: %w", err)
 	}
 
-	return &AnalyticsResponse{
-		Data:       response.Data,
+	return &AnalyticsDevicesResponse{
+		Data:       filterMinQueries(response.Data, request.Options),
 		Pagination: response.Meta.Pagination,
 	}, nil
 }
@@ -339,7 +721,10 @@ func (s *analyticsService) GetDevices(ctx context.Context, request *GetAnalytics
 // GetDevicesSeries returns connected devices and query distribution as time series.
 func (s *analyticsService) GetDevicesSeries(ctx context.Context, request *GetAnalyticsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error) {
 	path := analyticsPath(request.ProfileID, "devices;series")
-	query := buildTimeSeriesQuery(request.Options)
+	query, err := buildTimeSeriesStatusQuery(request.Options, request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics devices series: %w", err)
+	}
 
 	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
 	if err != nil {
@@ -359,13 +744,321 @@ func (s *analyticsService) GetDevicesSeries(ctx context.Context, request *GetAna
 	}, nil
 }
 
+// GetIPs returns the client IP addresses with their network and location.
+func (s *analyticsService) GetIPs(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsIPsResponse, error) {
+	path := analyticsPath(request.ProfileID, "ips")
+	query, err := buildAnalyticsStatusQuery(request.Options, request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics ips: %w", err)
+	}
+
+	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to get analytics ips: %w", err)
+	}
+
+	response := analyticsIPsResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to get analytics ips: %w", err)
+	}
+
+	return &AnalyticsIPsResponse{
+		Data:       filterMinQueries(response.Data, request.Options),
+		Pagination: response.Meta.Pagination,
+	}, nil
+}
+
+// GetIPsSeries returns the client IP addresses as time series.
+func (s *analyticsService) GetIPsSeries(ctx context.Context, request *GetAnalyticsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error) {
+	path := analyticsPath(request.ProfileID, "ips;series")
+	query, err := buildTimeSeriesStatusQuery(request.Options, request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics ips series: %w", err)
+	}
+
+	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to get analytics ips series: %w", err)
+	}
+
+	response := analyticsTimeSeriesResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to get analytics ips series: %w", err)
+	}
+
+	return &AnalyticsTimeSeriesResponse{
+		Data:       response.Data,
+		Pagination: response.Meta.Pagination,
+		Series:     response.Meta.Series,
+	}, nil
+}
+
+// GetDNSSEC returns queries by DNSSEC validation.
+func (s *analyticsService) GetDNSSEC(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsResponse, error) {
+	path := analyticsPath(request.ProfileID, "dnssec")
+	query, err := buildAnalyticsStatusQuery(request.Options, request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics dnssec: %w", err)
+	}
+
+	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to get analytics dnssec: %w", err)
+	}
+
+	response := analyticsResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to get analytics dnssec: %w", err)
+	}
+
+	return &AnalyticsResponse{
+		Data:       filterMinQueries(response.Data, request.Options),
+		Pagination: response.Meta.Pagination,
+	}, nil
+}
+
+// GetDNSSECSeries returns queries by DNSSEC validation as time series.
+func (s *analyticsService) GetDNSSECSeries(ctx context.Context, request *GetAnalyticsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error) {
+	path := analyticsPath(request.ProfileID, "dnssec;series")
+	query, err := buildTimeSeriesStatusQuery(request.Options, request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics dnssec series: %w", err)
+	}
+
+	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to get analytics dnssec series: %w", err)
+	}
+
+	response := analyticsTimeSeriesResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to get analytics dnssec series: %w", err)
+	}
+
+	return &AnalyticsTimeSeriesResponse{
+		Data:       response.Data,
+		Pagination: response.Meta.Pagination,
+		Series:     response.Meta.Series,
+	}, nil
+}
+
+// GetEncryption returns queries by encryption.
+func (s *analyticsService) GetEncryption(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsResponse, error) {
+	path := analyticsPath(request.ProfileID, "encryption")
+	query, err := buildAnalyticsStatusQuery(request.Options, request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics encryption: %w", err)
+	}
+
+	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to get analytics encryption: %w", err)
+	}
+
+	response := analyticsResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to get analytics encryption: %w", err)
+	}
+
+	return &AnalyticsResponse{
+		Data:       filterMinQueries(response.Data, request.Options),
+		Pagination: response.Meta.Pagination,
+	}, nil
+}
+
+// GetEncryptionSeries returns queries by encryption as time series.
+func (s *analyticsService) GetEncryptionSeries(ctx context.Context, request *GetAnalyticsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error) {
+	path := analyticsPath(request.ProfileID, "encryption;series")
+	query, err := buildTimeSeriesStatusQuery(request.Options, request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics encryption series: %w", err)
+	}
+
+	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to get analytics encryption series: %w", err)
+	}
+
+	response := analyticsTimeSeriesResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to get analytics encryption series: %w", err)
+	}
+
+	return &AnalyticsTimeSeriesResponse{
+		Data:       response.Data,
+		Pagination: response.Meta.Pagination,
+		Series:     response.Meta.Series,
+	}, nil
+}
+
+// GetIPVersions returns queries by IP version.
+func (s *analyticsService) GetIPVersions(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsResponse, error) {
+	path := analyticsPath(request.ProfileID, "ipVersions")
+	query, err := buildAnalyticsStatusQuery(request.Options, request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics ip versions: %w", err)
+	}
+
+	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to get analytics ip versions: %w", err)
+	}
+
+	response := analyticsResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to get analytics ip versions: %w", err)
+	}
+
+	return &AnalyticsResponse{
+		Data:       filterMinQueries(response.Data, request.Options),
+		Pagination: response.Meta.Pagination,
+	}, nil
+}
+
+// GetIPVersionsSeries returns queries by IP version as time series.
+func (s *analyticsService) GetIPVersionsSeries(ctx context.Context, request *GetAnalyticsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error) {
+	path := analyticsPath(request.ProfileID, "ipVersions;series")
+	query, err := buildTimeSeriesStatusQuery(request.Options, request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics ip versions series: %w", err)
+	}
+
+	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to get analytics ip versions series: %w", err)
+	}
+
+	response := analyticsTimeSeriesResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to get analytics ip versions series: %w", err)
+	}
+
+	return &AnalyticsTimeSeriesResponse{
+		Data:       response.Data,
+		Pagination: response.Meta.Pagination,
+		Series:     response.Meta.Series,
+	}, nil
+}
+
+// GetProtocols returns queries by DNS protocol.
+func (s *analyticsService) GetProtocols(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsResponse, error) {
+	path := analyticsPath(request.ProfileID, "protocols")
+	query, err := buildAnalyticsStatusQuery(request.Options, request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics protocols: %w", err)
+	}
+
+	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to get analytics protocols: %w", err)
+	}
+
+	response := analyticsResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to get analytics protocols: %w", err)
+	}
+
+	return &AnalyticsResponse{
+		Data:       filterMinQueries(response.Data, request.Options),
+		Pagination: response.Meta.Pagination,
+	}, nil
+}
+
+// GetProtocolsSeries returns queries by DNS protocol as time series.
+func (s *analyticsService) GetProtocolsSeries(ctx context.Context, request *GetAnalyticsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error) {
+	path := analyticsPath(request.ProfileID, "protocols;series")
+	query, err := buildTimeSeriesStatusQuery(request.Options, request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics protocols series: %w", err)
+	}
+
+	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to get analytics protocols series: %w", err)
+	}
+
+	response := analyticsTimeSeriesResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to get analytics protocols series: %w", err)
+	}
+
+	return &AnalyticsTimeSeriesResponse{
+		Data:       response.Data,
+		Pagination: response.Meta.Pagination,
+		Series:     response.Meta.Series,
+	}, nil
+}
+
+// GetReasons returns queries by block reason.
+func (s *analyticsService) GetReasons(ctx context.Context, request *GetAnalyticsRequest) (*AnalyticsResponse, error) {
+	path := analyticsPath(request.ProfileID, "reasons")
+	query, err := buildAnalyticsStatusQuery(request.Options, request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics reasons: %w", err)
+	}
+
+	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to get analytics reasons: %w", err)
+	}
+
+	response := analyticsResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to get analytics reasons: %w", err)
+	}
+
+	return &AnalyticsResponse{
+		Data:       filterMinQueries(response.Data, request.Options),
+		Pagination: response.Meta.Pagination,
+	}, nil
+}
+
+// GetReasonsSeries returns queries by block reason as time series.
+func (s *analyticsService) GetReasonsSeries(ctx context.Context, request *GetAnalyticsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error) {
+	path := analyticsPath(request.ProfileID, "reasons;series")
+	query, err := buildTimeSeriesStatusQuery(request.Options, request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics reasons series: %w", err)
+	}
+
+	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to get analytics reasons series: %w", err)
+	}
+
+	response := analyticsTimeSeriesResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to get analytics reasons series: %w", err)
+	}
+
+	return &AnalyticsTimeSeriesResponse{
+		Data:       response.Data,
+		Pagination: response.Meta.Pagination,
+		Series:     response.Meta.Series,
+	}, nil
+}
+
 // GetDestinations returns queries by country or GAFAM company.
 func (s *analyticsService) GetDestinations(ctx context.Context, request *GetAnalyticsDestinationsRequest) (*AnalyticsResponse, error) {
+	if err := validateDestinationsType(request.Type); err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics destinations: %w", err)
+	}
+
 	path := analyticsPath(request.ProfileID, "destinations")
 	query := buildAnalyticsQuery(request.Options)
-	if request.Type != "" {
-		query.Set("type", request.Type)
-	}
+	query.Set("type", request.Type)
 
 	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
 	if err != nil {
@@ -379,18 +1072,23 @@ func (s *analyticsService) GetDestinations(ctx context.Context, request *GetAnal
 	}
 
 	return &AnalyticsResponse{
-		Data:       response.Data,
+		Data:       filterMinQueries(response.Data, request.Options),
 		Pagination: response.Meta.Pagination,
 	}, nil
 }
 
 // GetDestinationsSeries returns queries by country or GAFAM company as time series.
 func (s *analyticsService) GetDestinationsSeries(ctx context.Context, request *GetAnalyticsDestinationsTimeSeriesRequest) (*AnalyticsTimeSeriesResponse, error) {
+	if err := validateDestinationsType(request.Type); err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics destinations series: %w", err)
+	}
+	if err := validateTimeSeriesTimezone(request.Options); err != nil {
+		return nil, fmt.Errorf("error validating request to get analytics destinations series: %w", err)
+	}
+
 	path := analyticsPath(request.ProfileID, "destinations;series")
 	query := buildTimeSeriesQuery(request.Options)
-	if request.Type != "" {
-		query.Set("type", request.Type)
-	}
+	query.Set("type", request.Type)
 
 	req, err := s.client.newRequestWithQuery(http.MethodGet, path, query, nil)
 	if err != nil {
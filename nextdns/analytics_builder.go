@@ -0,0 +1,90 @@
+package nextdns
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TimeSeriesOptionsBuilder builds validated AnalyticsTimeSeriesOptions. The setters can be chained,
+// and the invalid values are reported by Build.
+type TimeSeriesOptionsBuilder struct {
+	options AnalyticsTimeSeriesOptions
+	errs    []error
+}
+
+// NewTimeSeriesOptionsBuilder returns a new builder for AnalyticsTimeSeriesOptions.
+func NewTimeSeriesOptionsBuilder() *TimeSeriesOptionsBuilder {
+	return &TimeSeriesOptionsBuilder{}
+}
+
+// From sets the date filter start (ISO 8601, Unix timestamp, or relative like "-7d").
+func (b *TimeSeriesOptionsBuilder) From(from string) *TimeSeriesOptionsBuilder {
+	b.options.From = from
+	return b
+}
+
+// To sets the date filter end.
+func (b *TimeSeriesOptionsBuilder) To(to string) *TimeSeriesOptionsBuilder {
+	b.options.To = to
+	return b
+}
+
+// Device sets the device ID filter.
+func (b *TimeSeriesOptionsBuilder) Device(device string) *TimeSeriesOptionsBuilder {
+	b.options.Device = device
+	return b
+}
+
+// Interval sets the duration of each window of the series, in whole seconds.
+func (b *TimeSeriesOptionsBuilder) Interval(interval time.Duration) *TimeSeriesOptionsBuilder {
+	if interval < time.Second || interval%time.Second != 0 {
+		b.errs = append(b.errs, fmt.Errorf("invalid interval %s: must be a positive number of seconds", interval))
+		return b
+	}
+	b.options.Interval = strconv.FormatInt(int64(interval/time.Second), 10)
+	return b
+}
+
+// Timezone sets the IANA timezone used to align the windows, e.g. "America/New_York".
+func (b *TimeSeriesOptionsBuilder) Timezone(timezone string) *TimeSeriesOptionsBuilder {
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" || timezone == "Local" {
+		b.errs = append(b.errs, fmt.Errorf("invalid timezone %q: must be an IANA timezone", timezone))
+		return b
+	}
+	b.options.Timezone = timezone
+	return b
+}
+
+// Alignment sets how the windows are aligned: "start", "end", or "clock".
+func (b *TimeSeriesOptionsBuilder) Alignment(alignment string) *TimeSeriesOptionsBuilder {
+	switch alignment {
+	case "start", "end", "clock":
+		b.options.Alignment = alignment
+	default:
+		b.errs = append(b.errs, fmt.Errorf("invalid alignment %q: must be \"start\", \"end\" or \"clock\"", alignment))
+	}
+	return b
+}
+
+// Partials sets which incomplete windows are included: "none", "start", "end", or "all".
+func (b *TimeSeriesOptionsBuilder) Partials(partials string) *TimeSeriesOptionsBuilder {
+	switch partials {
+	case "none", "start", "end", "all":
+		b.options.Partials = partials
+	default:
+		b.errs = append(b.errs, fmt.Errorf("invalid partials %q: must be \"none\", \"start\", \"end\" or \"all\"", partials))
+	}
+	return b
+}
+
+// Build returns the built options, or the errors of the invalid values.
+func (b *TimeSeriesOptionsBuilder) Build() (*AnalyticsTimeSeriesOptions, error) {
+	if len(b.errs) > 0 {
+		return nil, errors.Join(b.errs...)
+	}
+
+	options := b.options
+	return &options, nil
+}
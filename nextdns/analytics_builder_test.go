@@ -0,0 +1,50 @@
+package nextdns
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestTimeSeriesOptionsBuilder(t *testing.T) {
+	c := is.New(t)
+
+	opts, err := NewTimeSeriesOptionsBuilder().
+		From("-7d").
+		To("now").
+		Device("8TD1G").
+		Interval(time.Hour).
+		Timezone("America/New_York").
+		Alignment("clock").
+		Partials("all").
+		Build()
+
+	c.NoErr(err)
+	c.Equal(opts, &AnalyticsTimeSeriesOptions{
+		AnalyticsOptions: AnalyticsOptions{From: "-7d", To: "now", Device: "8TD1G"},
+		Interval:         "3600",
+		Alignment:        "clock",
+		Timezone:         "America/New_York",
+		Partials:         "all",
+	})
+	c.Equal(buildTimeSeriesQuery(opts).Get("interval"), "3600")
+}
+
+func TestTimeSeriesOptionsBuilderInvalid(t *testing.T) {
+	c := is.New(t)
+
+	opts, err := NewTimeSeriesOptionsBuilder().
+		Interval(1500 * time.Millisecond).
+		Timezone("Mars/Olympus_Mons").
+		Alignment("middle").
+		Partials("all").
+		Build()
+
+	c.Equal(opts, nil)
+	c.True(strings.Contains(err.Error(), "invalid interval 1.5s"))
+	c.True(strings.Contains(err.Error(), `invalid timezone "Mars/Olympus_Mons"`))
+	c.True(strings.Contains(err.Error(), `invalid alignment "middle"`))
+	c.True(!strings.Contains(err.Error(), "partials"))
+}
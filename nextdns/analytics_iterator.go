@@ -0,0 +1,58 @@
+package nextdns
+
+import "context"
+
+// AnalyticsIterator walks through the entries of a paginated analytics endpoint, fetching the
+// next page when the current one is exhausted.
+//
+//	it := client.Analytics.IterateDomains(&nextdns.GetAnalyticsDomainsRequest{ProfileID: "abc123"})
+//	for it.Next(ctx) {
+//		entry := it.Entry()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type AnalyticsIterator struct {
+	pages *pageIterator[*AnalyticsEntry]
+}
+
+// Next advances to the next entry, fetching the next page if needed. It returns false when there
+// are no more entries or an error occurred, which is then returned by Err.
+func (it *AnalyticsIterator) Next(ctx context.Context) bool {
+	return it.pages.next(ctx)
+}
+
+// Entry returns the current entry.
+func (it *AnalyticsIterator) Entry() *AnalyticsEntry {
+	return it.pages.entry()
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *AnalyticsIterator) Err() error {
+	return it.pages.err
+}
+
+// IterateDomains returns an iterator over the top queried domains. The request is copied, and
+// only the cursor of the copy changes between the pages. No request is made until the first call
+// to Next, so the context is given to Next rather than here: each page is fetched with the
+// context of the call that needs it.
+func (s *analyticsService) IterateDomains(request *GetAnalyticsDomainsRequest) *AnalyticsIterator {
+	req := *request
+	options := &AnalyticsOptions{}
+	if request.Options != nil {
+		*options = *request.Options
+	}
+	req.Options = options
+
+	return &AnalyticsIterator{
+		pages: newPageIterator(options.Cursor, func(ctx context.Context, cursor string) ([]*AnalyticsEntry, string, error) {
+			req.Options.Cursor = cursor
+			response, err := s.GetDomains(ctx, &req)
+			if err != nil {
+				return nil, "", err
+			}
+			return response.Data, response.Pagination.Cursor, nil
+		}),
+	}
+}
@@ -0,0 +1,116 @@
+package nextdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestAnalyticsIterateDomains(t *testing.T) {
+	c := is.New(t)
+
+	pages := map[string]string{
+		"":      `{"data": [{"id": "google.com", "queries": 30}, {"id": "apple.com", "queries": 20}], "meta": {"pagination": {"cursor": "page2"}}}`,
+		"page2": `{"data": [{"id": "github.com", "queries": 10}], "meta": {"pagination": {"cursor": null}}}`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.URL.Path, "/profiles/abc123/analytics/domains")
+		c.Equal(r.URL.Query().Get("status"), "blocked")
+		c.Equal(r.URL.Query().Get("limit"), "2")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(pages[r.URL.Query().Get("cursor")]))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	request := &GetAnalyticsDomainsRequest{
+		ProfileID: "abc123",
+		Options:   &AnalyticsOptions{Limit: 2},
+		Status:    "blocked",
+	}
+	it := client.Analytics.IterateDomains(request)
+
+	ctx := context.Background()
+	var domains []string
+	for it.Next(ctx) {
+		domains = append(domains, it.Entry().ID)
+	}
+
+	c.NoErr(it.Err())
+	c.Equal(domains, []string{"google.com", "apple.com", "github.com"})
+	c.Equal(request.Options.Cursor, "") // the request of the caller is not modified
+	c.True(!it.Next(ctx))
+}
+
+func TestAnalyticsIterateDomainsError(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"data": [{"id": "google.com", "queries": 30}], "meta": {"pagination": {"cursor": "page2"}}}`))
+			c.NoErr(err)
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err := w.Write([]byte(`{"errors": [{"code": "internal"}]}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	it := client.Analytics.IterateDomains(&GetAnalyticsDomainsRequest{ProfileID: "abc123"})
+
+	ctx := context.Background()
+	count := 0
+	for it.Next(ctx) {
+		count++
+	}
+
+	c.Equal(count, 1)
+	c.True(it.Err() != nil)
+	c.Equal(it.Entry(), nil)
+}
+
+func TestAnalyticsIterateDomainsStableCursor(t *testing.T) {
+	c := is.New(t)
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		// The first page keeps returning its own cursor.
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": [{"id": "google.com", "queries": 30}], "meta": {"pagination": {"cursor": "same"}}}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	it := client.Analytics.IterateDomains(&GetAnalyticsDomainsRequest{
+		ProfileID: "abc123",
+		Options:   &AnalyticsOptions{Cursor: "same"},
+	})
+
+	ctx := context.Background()
+	var domains []string
+	for it.Next(ctx) {
+		domains = append(domains, it.Entry().ID)
+	}
+
+	c.NoErr(it.Err())
+	c.Equal(domains, []string{"google.com"})
+	c.Equal(calls, 1)
+}
@@ -3,9 +3,12 @@ package nextdns
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/matryer/is"
 )
@@ -29,7 +32,7 @@ func TestAnalyticsResponseUnmarshal(t *testing.T) {
 
 	c.Equal(len(resp.Data), 2)
 	c.Equal(resp.Data[0].ID, "default")
-	c.Equal(resp.Data[0].Queries, 1000)
+	c.Equal(resp.Data[0].Queries, int64(1000))
 	c.Equal(resp.Data[1].Name, "Blocked")
 	c.Equal(resp.Meta.Pagination.Cursor, "abc123")
 }
@@ -57,11 +60,25 @@ func TestAnalyticsTimeSeriesResponseUnmarshal(t *testing.T) {
 	c.Equal(len(resp.Data), 1)
 	c.Equal(resp.Data[0].ID, "default")
 	c.Equal(len(resp.Data[0].Queries), 3)
-	c.Equal(resp.Data[0].Queries[0], 100)
+	c.Equal(resp.Data[0].Queries[0], int64(100))
 	c.Equal(resp.Meta.Series.Interval, 3600)
 	c.Equal(len(resp.Meta.Series.Times), 3)
 }
 
+func TestAnalyticsResponseUnmarshalNullQueries(t *testing.T) {
+	c := is.New(t)
+
+	var resp analyticsResponse
+	err := json.Unmarshal([]byte(`{"data": [{"id": "default", "queries": null}, {"id": "blocked", "queries": 5}]}`), &resp)
+	c.NoErr(err)
+	c.Equal(resp.Data, []*AnalyticsEntry{{ID: "default"}, {ID: "blocked", Queries: 5}})
+
+	var series analyticsTimeSeriesResponse
+	err = json.Unmarshal([]byte(`{"data": [{"id": "default", "queries": [100, null, 200]}]}`), &series)
+	c.NoErr(err)
+	c.Equal(series.Data[0].Queries, []int64{100, 0, 200})
+}
+
 func TestAnalyticsGetStatus(t *testing.T) {
 	c := is.New(t)
 
@@ -94,7 +111,7 @@ func TestAnalyticsGetStatus(t *testing.T) {
 	c.NoErr(err)
 	c.Equal(len(resp.Data), 3)
 	c.Equal(resp.Data[0].ID, "default")
-	c.Equal(resp.Data[0].Queries, 1000)
+	c.Equal(resp.Data[0].Queries, int64(1000))
 }
 
 func TestAnalyticsGetStatusWithOptions(t *testing.T) {
@@ -371,3 +388,914 @@ func TestAnalyticsGetDestinationsSeries(t *testing.T) {
 	c.Equal(len(resp.Data), 1)
 	c.Equal(resp.Data[0].Name, "Google")
 }
+
+func TestAnalyticsSeriesUTCTimes(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.URL.Query().Get("timezone"), "America/New_York")
+		c.Equal(r.URL.Query().Get("alignment"), "clock")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{
+			"data": [{"id": "default", "queries": [100, 150]}],
+			"meta": {
+				"pagination": {"cursor": ""},
+				"series": {
+					"times": ["2024-01-01T00:00:00-05:00", "2024-01-02T00:00:00-05:00"],
+					"interval": 86400
+				}
+			}
+		}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	resp, err := client.Analytics.GetStatusSeries(ctx, &GetAnalyticsTimeSeriesRequest{
+		ProfileID: "abc123",
+		Options: &AnalyticsTimeSeriesOptions{
+			Interval:  "1d",
+			Alignment: "clock",
+			Timezone:  "America/New_York",
+		},
+	})
+	c.NoErr(err)
+
+	times, err := resp.Series.UTCTimes()
+	c.NoErr(err)
+	c.Equal(len(times), 2)
+	c.Equal(times[0], time.Date(2024, 1, 1, 5, 0, 0, 0, time.UTC))
+	c.Equal(times[1], time.Date(2024, 1, 2, 5, 0, 0, 0, time.UTC))
+	c.Equal(times[0].Location(), time.UTC)
+
+	_, err = AnalyticsSeriesInfo{Times: []string{"not-a-time"}}.UTCTimes()
+	c.True(err != nil)
+}
+
+func TestAnalyticsLargeQueryCounts(t *testing.T) {
+	c := is.New(t)
+
+	jsonData := `{
+		"data": [
+			{"id": "default", "queries": 9999999999}
+		],
+		"meta": {"pagination": {"cursor": ""}}
+	}`
+
+	var resp analyticsResponse
+	err := json.Unmarshal([]byte(jsonData), &resp)
+	c.NoErr(err)
+	c.Equal(resp.Data[0].Queries, int64(9999999999))
+
+	jsonData = `{
+		"data": [
+			{"id": "default", "queries": [9999999999, 10000000001]}
+		],
+		"meta": {"pagination": {"cursor": ""}, "series": {"times": [], "interval": 86400}}
+	}`
+
+	var seriesResp analyticsTimeSeriesResponse
+	err = json.Unmarshal([]byte(jsonData), &seriesResp)
+	c.NoErr(err)
+	c.Equal(seriesResp.Data[0].Queries, []int64{9999999999, 10000000001})
+}
+
+func TestAnalyticsDevicesResponseUnmarshal(t *testing.T) {
+	c := is.New(t)
+
+	jsonData := `{
+		"data": [
+			{"id": "8TD1G", "name": "Romain's iPhone", "model": "Apple iPhone 13", "localIp": "192.168.1.2", "queries": 318},
+			{"id": "__UNIDENTIFIED__", "queries": 20}
+		],
+		"meta": {"pagination": {"cursor": ""}}
+	}`
+
+	var resp analyticsDevicesResponse
+	err := json.Unmarshal([]byte(jsonData), &resp)
+	c.NoErr(err)
+
+	c.Equal(len(resp.Data), 2)
+	c.Equal(resp.Data[0].Model, "Apple iPhone 13")
+	c.Equal(resp.Data[0].LocalIP, "192.168.1.2")
+	c.Equal(resp.Data[0].Queries, int64(318))
+	c.Equal(resp.Data[1].Model, "")
+}
+
+func TestAnalyticsGetDestinationsSeriesInvalidType(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("no request expected for an invalid destinations type")
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	_, err = client.Analytics.GetDestinationsSeries(ctx, &GetAnalyticsDestinationsTimeSeriesRequest{
+		ProfileID: "abc123",
+	})
+	c.True(errors.Is(err, ErrInvalidDestinationsType))
+
+	_, err = client.Analytics.GetDestinationsSeries(ctx, &GetAnalyticsDestinationsTimeSeriesRequest{
+		ProfileID: "abc123",
+		Type:      "continents",
+	})
+	c.True(errors.Is(err, ErrInvalidDestinationsType))
+
+	_, err = client.Analytics.GetDestinations(ctx, &GetAnalyticsDestinationsRequest{
+		ProfileID: "abc123",
+	})
+	c.True(errors.Is(err, ErrInvalidDestinationsType))
+}
+
+func TestAnalyticsGetStatusMulti(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.URL.Query().Get("from"), "-1d")
+
+		switch r.URL.Path {
+		case "/profiles/abc123/analytics/status":
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"data": [{"id": "default", "queries": 100}], "meta": {"pagination": {"cursor": ""}}}`))
+			c.NoErr(err)
+		case "/profiles/def456/analytics/status":
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"data": [{"id": "blocked", "queries": 7}], "meta": {"pagination": {"cursor": ""}}}`))
+			c.NoErr(err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"errors": [{"code": "notFound"}]}`))
+			c.NoErr(err)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	opts := &AnalyticsOptions{From: "-1d"}
+
+	responses, err := client.Analytics.GetStatusMulti(ctx, []string{"abc123", "def456"}, opts)
+	c.NoErr(err)
+	c.Equal(len(responses), 2)
+	c.Equal(responses["abc123"].Data[0].Queries, int64(100))
+	c.Equal(responses["def456"].Data[0].ID, "blocked")
+
+	responses, err = client.Analytics.GetStatusMulti(ctx, []string{"abc123", "missing"}, opts)
+	c.True(IsNotFound(err))
+	c.True(strings.Contains(err.Error(), "profile missing"))
+	c.Equal(len(responses), 1)
+	c.True(responses["abc123"] != nil)
+}
+
+func TestAnalyticsGetStatusForGroup(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.URL.Path, "/profiles/abc123/analytics/status")
+		c.Equal(r.URL.Query().Get("from"), "-1d")
+
+		var resp string
+		switch r.URL.Query().Get("device") {
+		case "tablet":
+			resp = `{"data": [{"id": "default", "queries": 100}, {"id": "blocked", "queries": 20}], "meta": {"pagination": {"cursor": ""}}}`
+		case "console":
+			resp = `{"data": [{"id": "default", "queries": 50}, {"id": "allowed", "queries": 5}, {"id": "blocked", "queries": 2}], "meta": {"pagination": {"cursor": ""}}}`
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"errors": [{"code": "notFound"}]}`))
+			c.NoErr(err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	opts := &AnalyticsOptions{From: "-1d", Device: "ignored"}
+	group := DeviceGroup{Name: "Kids' devices", DeviceIDs: []string{"tablet", "console"}}
+
+	response, err := client.Analytics.GetStatusForGroup(ctx, "abc123", group, opts)
+	c.NoErr(err)
+	c.Equal(response.Data, []*AnalyticsEntry{
+		{ID: "default", Queries: 150},
+		{ID: "blocked", Queries: 22},
+		{ID: "allowed", Queries: 5},
+	})
+	c.Equal(opts.Device, "ignored")
+
+	// The filter applies to the group totals, and a device listed twice is counted once.
+	group.DeviceIDs = append(group.DeviceIDs, "console")
+	response, err = client.Analytics.GetStatusForGroup(ctx, "abc123", group, &AnalyticsOptions{From: "-1d", MinQueries: 10})
+	c.NoErr(err)
+	c.Equal(response.Data, []*AnalyticsEntry{
+		{ID: "default", Queries: 150},
+		{ID: "blocked", Queries: 22},
+	})
+
+	group.DeviceIDs = append(group.DeviceIDs, "missing")
+	response, err = client.Analytics.GetStatusForGroup(ctx, "abc123", group, opts)
+	c.True(IsNotFound(err))
+	c.True(strings.Contains(err.Error(), "device missing"))
+	c.True(response == nil)
+}
+
+func TestAnalyticsGetReasons(t *testing.T) {
+	c := is.New(t)
+
+	tests := []struct {
+		path    string
+		resp    string
+		options *AnalyticsOptions
+		query   map[string]string
+	}{
+		{
+			path: "/profiles/abc123/analytics/reasons",
+			resp: `{"data": [{"id": "blocklist:nextdns-recommended", "name": "NextDNS Ads & Trackers Blocklist", "queries": 4812}, {"id": "native:apple", "name": "Native Tracking (Apple)", "queries": 117}], "meta": {"pagination": {"cursor": null}}}`,
+		},
+		{
+			path:    "/profiles/abc123/analytics/reasons",
+			resp:    `{"data": [{"id": "blocklist:nextdns-recommended", "name": "NextDNS Ads & Trackers Blocklist", "queries": 4812}, {"id": "native:apple", "name": "Native Tracking (Apple)", "queries": 117}], "meta": {"pagination": {"cursor": "next"}}}`,
+			options: &AnalyticsOptions{From: "-7d", To: "now", Limit: 2, Cursor: "abc", Device: "8TD1G"},
+			query:   map[string]string{"from": "-7d", "to": "now", "limit": "2", "cursor": "abc", "device": "8TD1G"},
+		},
+	}
+
+	for _, tt := range tests {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c.Equal(r.Method, "GET")
+			c.Equal(r.URL.Path, tt.path)
+			for key, value := range tt.query {
+				c.Equal(r.URL.Query().Get(key), value)
+			}
+
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(tt.resp))
+			c.NoErr(err)
+		}))
+
+		client, err := New(WithBaseURL(ts.URL))
+		c.NoErr(err)
+
+		resp, err := client.Analytics.GetReasons(context.Background(), &GetAnalyticsRequest{
+			ProfileID: "abc123",
+			Options:   tt.options,
+		})
+		ts.Close()
+
+		c.NoErr(err)
+		c.Equal(len(resp.Data), 2)
+		c.Equal(resp.Data[0].ID, "blocklist:nextdns-recommended")
+		c.Equal(resp.Data[0].Name, "NextDNS Ads & Trackers Blocklist")
+		c.Equal(resp.Data[0].Queries, int64(4812))
+		c.Equal(resp.Data[1].Name, "Native Tracking (Apple)")
+		c.Equal(resp.Data[1].Queries, int64(117))
+	}
+}
+
+func TestAnalyticsGetReasonsSeries(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/analytics/reasons;series")
+		c.Equal(r.URL.Query().Get("from"), "-1d")
+		c.Equal(r.URL.Query().Get("device"), "8TD1G")
+		c.Equal(r.URL.Query().Get("interval"), "1h")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{
+			"data": [
+				{"id": "blocklist:oisd", "name": "OISD", "queries": [12, 0, 7]}
+			],
+			"meta": {
+				"pagination": {"cursor": ""},
+				"series": {
+					"times": ["2024-01-01T00:00:00Z", "2024-01-01T01:00:00Z", "2024-01-01T02:00:00Z"],
+					"interval": 3600
+				}
+			}
+		}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	resp, err := client.Analytics.GetReasonsSeries(context.Background(), &GetAnalyticsTimeSeriesRequest{
+		ProfileID: "abc123",
+		Options: &AnalyticsTimeSeriesOptions{
+			AnalyticsOptions: AnalyticsOptions{From: "-1d", Device: "8TD1G"},
+			Interval:         "1h",
+		},
+	})
+
+	c.NoErr(err)
+	c.Equal(len(resp.Data), 1)
+	c.Equal(resp.Data[0].Name, "OISD")
+	c.Equal(resp.Data[0].Queries, []int64{12, 0, 7})
+	c.Equal(resp.Series.Interval, 3600)
+}
+
+func TestAnalyticsResponsePercentages(t *testing.T) {
+	c := is.New(t)
+
+	resp := &AnalyticsResponse{
+		Data: []*AnalyticsEntry{
+			{ID: "default", Queries: 600},
+			{ID: "blocked", Queries: 300},
+			{ID: "allowed", Queries: 100},
+		},
+	}
+	c.Equal(resp.Percentages(), map[string]float64{
+		"default": 60,
+		"blocked": 30,
+		"allowed": 10,
+	})
+
+	c.Equal(len((&AnalyticsResponse{}).Percentages()), 0)
+	c.Equal((&AnalyticsResponse{Data: []*AnalyticsEntry{{ID: "default"}}}).Percentages(), map[string]float64{})
+}
+
+func TestAnalyticsGetIPs(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/analytics/ips")
+		c.Equal(r.URL.Query().Get("limit"), "5")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{
+			"data": [
+				{
+					"ip": "91.171.12.34",
+					"network": {"cellular": false, "vpn": false, "isp": "Free SAS", "asn": 12322},
+					"geo": {"latitude": 48.8566, "longitude": 2.3522, "countryCode": "FR", "country": "France", "city": "Paris"},
+					"queries": 136919
+				}
+			],
+			"meta": {"pagination": {"cursor": null}}
+		}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	resp, err := client.Analytics.GetIPs(ctx, &GetAnalyticsRequest{
+		ProfileID: "abc123",
+		Options:   &AnalyticsOptions{Limit: 5},
+	})
+
+	c.NoErr(err)
+	c.Equal(len(resp.Data), 1)
+	c.Equal(resp.Data[0].ID, "91.171.12.34")
+	c.Equal(resp.Data[0].Queries, int64(136919))
+	c.Equal(resp.Data[0].Network, "Free SAS")
+	c.Equal(resp.Data[0].ASN, 12322)
+	c.Equal(resp.Data[0].GeoCountry, "France")
+	c.Equal(resp.Data[0].GeoCity, "Paris")
+}
+
+func TestAnalyticsGetIPsSeries(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/analytics/ips;series")
+		c.Equal(r.URL.Query().Get("interval"), "1d")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{
+			"data": [{"id": "91.171.12.34", "queries": [120, 98]}],
+			"meta": {
+				"pagination": {"cursor": ""},
+				"series": {"times": ["2024-01-01T00:00:00Z", "2024-01-02T00:00:00Z"], "interval": 86400}
+			}
+		}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	resp, err := client.Analytics.GetIPsSeries(ctx, &GetAnalyticsTimeSeriesRequest{
+		ProfileID: "abc123",
+		Options:   &AnalyticsTimeSeriesOptions{Interval: "1d"},
+	})
+
+	c.NoErr(err)
+	c.Equal(len(resp.Data), 1)
+	c.Equal(resp.Data[0].Queries, []int64{120, 98})
+	c.Equal(resp.Series.Interval, 86400)
+}
+
+func TestAnalyticsGetProtocols(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/analytics/protocols")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{"data": [{"id": "DNS-over-HTTPS", "queries": 958}, {"id": "UDP", "queries": 42}]}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	resp, err := client.Analytics.GetProtocols(ctx, &GetAnalyticsRequest{ProfileID: "abc123"})
+
+	c.NoErr(err)
+	c.Equal(len(resp.Data), 2)
+	c.Equal(resp.Data[0].ID, "DNS-over-HTTPS")
+	c.Equal(resp.Data[1].Queries, int64(42))
+}
+
+func TestAnalyticsGetProtocolsSeries(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/analytics/protocols;series")
+		c.Equal(r.URL.Query().Get("interval"), "1h")
+		c.Equal(r.URL.Query().Get("alignment"), "clock")
+		c.Equal(r.URL.Query().Get("timezone"), "Europe/Paris")
+		c.Equal(r.URL.Query().Get("partials"), "all")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{
+			"data": [
+				{"id": "DNS-over-HTTPS", "queries": [40, 52, 0, 61]},
+				{"id": "DNS-over-QUIC", "queries": [3, 0, 0, 5]}
+			],
+			"meta": {
+				"pagination": {"cursor": ""},
+				"series": {
+					"times": ["2024-01-01T00:00:00+01:00", "2024-01-01T01:00:00+01:00", "2024-01-01T02:00:00+01:00", "2024-01-01T03:00:00+01:00"],
+					"interval": 3600
+				}
+			}
+		}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	resp, err := client.Analytics.GetProtocolsSeries(ctx, &GetAnalyticsTimeSeriesRequest{
+		ProfileID: "abc123",
+		Options: &AnalyticsTimeSeriesOptions{
+			Interval:  "1h",
+			Alignment: "clock",
+			Timezone:  "Europe/Paris",
+			Partials:  "all",
+		},
+	})
+
+	c.NoErr(err)
+	c.Equal(len(resp.Data), 2)
+	c.Equal(resp.Data[0].Queries, []int64{40, 52, 0, 61})
+	c.Equal(resp.Data[1].ID, "DNS-over-QUIC")
+	c.Equal(len(resp.Series.Times), 4)
+}
+
+func TestAnalyticsGetIPVersions(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/analytics/ipVersions")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{"data": [{"id": "IPv4", "queries": 8213}, {"id": "IPv6", "queries": 1377}]}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	resp, err := client.Analytics.GetIPVersions(ctx, &GetAnalyticsRequest{ProfileID: "abc123"})
+
+	c.NoErr(err)
+	c.Equal(len(resp.Data), 2)
+	c.Equal(resp.Data[0].ID, "IPv4")
+	c.Equal(resp.Data[0].Queries, int64(8213))
+	c.Equal(resp.Data[1].ID, "IPv6")
+	c.Equal(resp.Data[1].Queries, int64(1377))
+}
+
+func TestAnalyticsGetIPVersionsSeries(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/analytics/ipVersions;series")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{
+			"data": [
+				{"id": "IPv4", "queries": [300, 280]},
+				{"id": "IPv6", "queries": [45, 60]}
+			],
+			"meta": {
+				"pagination": {"cursor": ""},
+				"series": {"times": ["2024-01-01T00:00:00Z", "2024-01-02T00:00:00Z"], "interval": 86400}
+			}
+		}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	resp, err := client.Analytics.GetIPVersionsSeries(ctx, &GetAnalyticsTimeSeriesRequest{ProfileID: "abc123"})
+
+	c.NoErr(err)
+	c.Equal(len(resp.Data), 2)
+	c.Equal(resp.Data[1].ID, "IPv6")
+	c.Equal(resp.Data[1].Queries, []int64{45, 60})
+}
+
+func TestAnalyticsGetDNSSEC(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/analytics/dnssec")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{"data": [{"id": "false", "queries": 9104}, {"id": "true", "queries": 486}]}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	resp, err := client.Analytics.GetDNSSEC(ctx, &GetAnalyticsRequest{ProfileID: "abc123"})
+
+	c.NoErr(err)
+	c.Equal(len(resp.Data), 2)
+	c.Equal(resp.Data[1].Queries, int64(486))
+}
+
+func TestAnalyticsGetDNSSECSeries(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/analytics/dnssec;series")
+		c.Equal(r.URL.Query().Get("interval"), "1d")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{
+			"data": [
+				{"id": "false", "queries": [1200, 1350]},
+				{"id": "true", "queries": [80, 64]}
+			],
+			"meta": {
+				"pagination": {"cursor": ""},
+				"series": {"times": ["2024-01-01T00:00:00Z", "2024-01-02T00:00:00Z"], "interval": 86400}
+			}
+		}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	resp, err := client.Analytics.GetDNSSECSeries(ctx, &GetAnalyticsTimeSeriesRequest{
+		ProfileID: "abc123",
+		Options:   &AnalyticsTimeSeriesOptions{Interval: "1d"},
+	})
+
+	c.NoErr(err)
+	c.Equal(len(resp.Data), 2)
+	c.Equal(resp.Series.Times, []string{"2024-01-01T00:00:00Z", "2024-01-02T00:00:00Z"})
+	c.Equal(resp.Series.Interval, 86400)
+}
+
+func TestAnalyticsGetEncryption(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/analytics/encryption")
+		c.Equal(r.URL.Query().Get("device"), "8TD1G")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{"data": [{"id": "true", "queries": 7321}, {"id": "false", "queries": 25}]}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	resp, err := client.Analytics.GetEncryption(ctx, &GetAnalyticsRequest{
+		ProfileID: "abc123",
+		Options:   &AnalyticsOptions{Device: "8TD1G"},
+	})
+
+	c.NoErr(err)
+	c.Equal(len(resp.Data), 2)
+	c.Equal(resp.Data[0].Queries, int64(7321))
+}
+
+func TestAnalyticsGetEncryptionSeries(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/analytics/encryption;series")
+		c.Equal(r.URL.Query().Get("device"), "8TD1G")
+		c.Equal(r.URL.Query().Get("interval"), "1h")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{
+			"data": [{"id": "true", "queries": [310, 295]}],
+			"meta": {
+				"pagination": {"cursor": ""},
+				"series": {"times": ["2024-01-01T00:00:00Z", "2024-01-01T01:00:00Z"], "interval": 3600}
+			}
+		}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	resp, err := client.Analytics.GetEncryptionSeries(ctx, &GetAnalyticsTimeSeriesRequest{
+		ProfileID: "abc123",
+		Options: &AnalyticsTimeSeriesOptions{
+			AnalyticsOptions: AnalyticsOptions{Device: "8TD1G"},
+			Interval:         "1h",
+		},
+	})
+
+	c.NoErr(err)
+	c.Equal(resp.Data[0].Queries, []int64{310, 295})
+}
+
+func TestAnalyticsMinQueries(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.URL.Query().Get("minQueries"), "") // applied client-side
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{"data": [{"id": "google.com", "queries": 120}, {"id": "apple.com", "queries": 10}, {"id": "rare.example.com", "queries": 2}]}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	resp, err := client.Analytics.GetDomains(ctx, &GetAnalyticsDomainsRequest{
+		ProfileID: "abc123",
+		Options:   &AnalyticsOptions{MinQueries: 10},
+	})
+
+	c.NoErr(err)
+	c.Equal(len(resp.Data), 2)
+	c.Equal(resp.Data[0].ID, "google.com")
+	c.Equal(resp.Data[1].ID, "apple.com")
+
+	resp, err = client.Analytics.GetDomains(ctx, &GetAnalyticsDomainsRequest{ProfileID: "abc123"})
+	c.NoErr(err)
+	c.Equal(len(resp.Data), 3)
+}
+
+func TestAnalyticsGetStatusSeriesMissingInterval(t *testing.T) {
+	c := is.New(t)
+
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": [], "meta": {"series": {"times": [], "interval": 86400}}}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	for _, opts := range []*AnalyticsTimeSeriesOptions{nil, {AnalyticsOptions: AnalyticsOptions{From: "-7d"}}} {
+		_, err = client.Analytics.GetStatusSeries(ctx, &GetAnalyticsTimeSeriesRequest{
+			ProfileID: "abc123",
+			Options:   opts,
+		})
+
+		var e *Error
+		c.True(errors.As(err, &e))
+		c.Equal(e.Type, ErrorTypeRequest)
+	}
+	c.Equal(calls, 0)
+
+	_, err = client.Analytics.GetStatusSeries(ctx, &GetAnalyticsTimeSeriesRequest{
+		ProfileID: "abc123",
+		Options:   &AnalyticsTimeSeriesOptions{Interval: "1d"},
+	})
+	c.NoErr(err)
+	c.Equal(calls, 1)
+}
+
+func TestAnalyticsStatusFilter(t *testing.T) {
+	c := is.New(t)
+
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.URL.Query().Get("status"), "blocked")
+		paths = append(paths, r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": [], "meta": {"series": {"times": [], "interval": 3600}}}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	_, err = client.Analytics.GetStatus(ctx, &GetAnalyticsRequest{ProfileID: "abc123", Status: AnalyticsStatusBlocked})
+	c.NoErr(err)
+	_, err = client.Analytics.GetProtocols(ctx, &GetAnalyticsRequest{ProfileID: "abc123", Status: AnalyticsStatusBlocked})
+	c.NoErr(err)
+	_, err = client.Analytics.GetProtocolsSeries(ctx, &GetAnalyticsTimeSeriesRequest{
+		ProfileID: "abc123",
+		Options:   &AnalyticsTimeSeriesOptions{Interval: "1h"},
+		Status:    AnalyticsStatusBlocked,
+	})
+	c.NoErr(err)
+
+	c.Equal(paths, []string{
+		"/profiles/abc123/analytics/status",
+		"/profiles/abc123/analytics/protocols",
+		"/profiles/abc123/analytics/protocols;series",
+	})
+}
+
+func TestAnalyticsStatusFilterInvalid(t *testing.T) {
+	c := is.New(t)
+
+	client, err := New(WithBaseURL("http://127.0.0.1:0"))
+	c.NoErr(err)
+
+	_, err = client.Analytics.GetProtocols(context.Background(), &GetAnalyticsRequest{ProfileID: "abc123", Status: "error"})
+	var e *Error
+	c.True(errors.As(err, &e))
+	c.Equal(e.Type, ErrorTypeRequest)
+	c.True(strings.Contains(err.Error(), "invalid analytics status"))
+}
+
+func TestAnalyticsGetDomainsByDevice(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.URL.Path, "/profiles/abc123/analytics/domains")
+		c.Equal(r.URL.Query().Get("from"), "-1d")
+
+		switch r.URL.Query().Get("device") {
+		case "laptop":
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"data": [{"id": "github.com", "queries": 42}], "meta": {"pagination": {"cursor": ""}}}`))
+			c.NoErr(err)
+		case "phone":
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"data": [{"id": "apple.com", "queries": 7}], "meta": {"pagination": {"cursor": ""}}}`))
+			c.NoErr(err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"errors": [{"code": "notFound"}]}`))
+			c.NoErr(err)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	opts := &AnalyticsOptions{From: "-1d", Device: "ignored"}
+
+	responses, err := client.Analytics.GetDomainsByDevice(ctx, "abc123", []string{"laptop", "phone"}, opts)
+	c.NoErr(err)
+	c.Equal(len(responses), 2)
+	c.Equal(responses["laptop"].Data[0].Queries, int64(42))
+	c.Equal(responses["phone"].Data[0].Queries, int64(7))
+	c.Equal(opts.Device, "ignored")
+
+	responses, err = client.Analytics.GetDomainsByDevice(ctx, "abc123", []string{"laptop", "missing"}, opts)
+	c.True(IsNotFound(err))
+	c.True(strings.Contains(err.Error(), "device missing"))
+	c.Equal(len(responses), 1)
+}
+
+func TestAnalyticsSeriesTimezoneAlignment(t *testing.T) {
+	c := is.New(t)
+
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": [], "meta": {"series": {"times": [], "interval": 86400}}}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	tests := []struct {
+		opts  AnalyticsTimeSeriesOptions
+		valid bool
+	}{
+		{opts: AnalyticsTimeSeriesOptions{Interval: "1d"}, valid: true},
+		{opts: AnalyticsTimeSeriesOptions{Interval: "1d", Alignment: "start"}, valid: true},
+		{opts: AnalyticsTimeSeriesOptions{Interval: "1d", Alignment: "clock", Timezone: "Europe/Paris"}, valid: true},
+		{opts: AnalyticsTimeSeriesOptions{Interval: "1d", Timezone: "Europe/Paris"}, valid: false},
+		{opts: AnalyticsTimeSeriesOptions{Interval: "1d", Alignment: "end", Timezone: "Europe/Paris"}, valid: false},
+		{opts: AnalyticsTimeSeriesOptions{Interval: "1d", Alignment: "end", Timezone: "Europe/Paris", SkipTimezoneCheck: true}, valid: true},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		calls = 0
+		_, err := client.Analytics.GetStatusSeries(ctx, &GetAnalyticsTimeSeriesRequest{
+			ProfileID: "abc123",
+			Options:   &tt.opts,
+		})
+		_, domainsErr := client.Analytics.GetDomainsSeries(ctx, &GetAnalyticsDomainsTimeSeriesRequest{
+			ProfileID: "abc123",
+			Options:   &tt.opts,
+		})
+
+		if tt.valid {
+			c.NoErr(err)
+			c.NoErr(domainsErr)
+			c.Equal(calls, 2)
+			continue
+		}
+
+		var e *Error
+		c.True(errors.As(err, &e))
+		c.Equal(e.Type, ErrorTypeRequest)
+		c.True(errors.As(domainsErr, &e))
+		c.Equal(e.Type, ErrorTypeRequest)
+		c.Equal(calls, 0)
+	}
+}
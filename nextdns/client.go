@@ -9,10 +9,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httputil"
 	"net/url"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-cleanhttp"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -21,11 +25,24 @@ const (
 	userAgent   = "nextdns-go"
 )
 
+// apiKeyHeaderRegexp matches the API key header in a request dump.
+var apiKeyHeaderRegexp = regexp.MustCompile(`(?mi)^X-Api-Key:.*$`)
+
+// RequestRecorder receives the raw dumps of a request and its response.
+type RequestRecorder func(reqDump, respDump []byte)
+
+// RequestInterceptor is called with every request before it's sent, e.g. to add tracing headers.
+// Returning an error aborts the request.
+type RequestInterceptor func(req *http.Request) error
+
 // Client represents a NextDNS client.
 type Client struct {
 	client  *http.Client
 	baseURL *url.URL
 
+	// Service for the Account.
+	Account AccountService
+
 	// Service for the Profile.
 	Profiles ProfilesService
 
@@ -68,15 +85,76 @@ type Client struct {
 
 	// Debug mode for the HTTP requests.
 	Debug bool
+
+	// recorder receives the dumps of every request and response, if set.
+	recorder RequestRecorder
+
+	// interceptors are called with every request before it's sent.
+	interceptors []RequestInterceptor
+
+	// serviceBaseURLs overrides the base URL of specific services.
+	serviceBaseURLs map[ServiceName]*url.URL
+
+	// acceptLanguage is the value of the Accept-Language header, if set.
+	acceptLanguage string
+
+	// userAgent is the value of the User-Agent header.
+	userAgent string
+
+	// rateLimiter tracks the rate limit reported by the API.
+	rateLimiter *rateLimiter
+
+	// limiter throttles the requests sent by the client, if set.
+	limiter *rate.Limiter
+
+	// logger receives the log lines of the requests, if set.
+	logger Logger
+
+	// stats records the requests sent by the client.
+	stats *stats
+
+	// timeout is the timeout of the requests sent without a deadline, if set.
+	timeout time.Duration
+
+	// retry retries the requests failing with a transient error, if set.
+	retry *retryPolicy
 }
 
+// ServiceName identifies a service of the client.
+type ServiceName string
+
+// ServiceName constants identify the services of the client.
+const (
+	ServiceAccount                   ServiceName = "account"
+	ServiceProfiles                  ServiceName = "profiles"
+	ServiceAllowlist                 ServiceName = "allowlist"
+	ServiceDenylist                  ServiceName = "denylist"
+	ServiceParentalControl           ServiceName = "parentalControl"
+	ServiceParentalControlServices   ServiceName = "parentalControlServices"
+	ServiceParentalControlCategories ServiceName = "parentalControlCategories"
+	ServicePrivacy                   ServiceName = "privacy"
+	ServicePrivacyBlocklists         ServiceName = "privacyBlocklists"
+	ServicePrivacyNatives            ServiceName = "privacyNatives"
+	ServiceSettings                  ServiceName = "settings"
+	ServiceSettingsLogs              ServiceName = "settingsLogs"
+	ServiceSettingsBlockPage         ServiceName = "settingsBlockPage"
+	ServiceSettingsPerformance       ServiceName = "settingsPerformance"
+	ServiceSecurity                  ServiceName = "security"
+	ServiceSecurityTlds              ServiceName = "securityTlds"
+	ServiceRewrites                  ServiceName = "rewrites"
+	ServiceSetup                     ServiceName = "setup"
+	ServiceSetupLinkedIP             ServiceName = "setupLinkedIP"
+	ServiceAnalytics                 ServiceName = "analytics"
+	ServiceLogs                      ServiceName = "logs"
+)
+
 // ClientOption is a function that can be used to customize the client.
 type ClientOption func(c *Client) error
 
 // WithBaseURL sets the base URL of the NextDNS API.
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) error {
-		parsedURL, err := url.Parse(baseURL)
+		parsedURL, err := parseBaseURL(baseURL)
 		if err != nil {
 			return err
 		}
@@ -86,6 +164,44 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// WithServiceBaseURL overrides the base URL of a single service, e.g. to point the Logs service
+// to a fake server in integration tests while the other services use the real API.
+func WithServiceBaseURL(service ServiceName, baseURL string) ClientOption {
+	return func(c *Client) error {
+		parsedURL, err := parseBaseURL(baseURL)
+		if err != nil {
+			return err
+		}
+
+		if c.serviceBaseURLs == nil {
+			c.serviceBaseURLs = make(map[ServiceName]*url.URL)
+		}
+		c.serviceBaseURLs[service] = parsedURL
+		return nil
+	}
+}
+
+// parseBaseURL parses a base URL, removing its query and fragment, and ensuring its path ends
+// with a slash so the API paths are resolved under it, e.g. "https://proxy/nextdns/profiles".
+func parseBaseURL(baseURL string) (*url.URL, error) {
+	parsedURL, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedURL.RawQuery = ""
+	parsedURL.ForceQuery = false
+	parsedURL.Fragment = ""
+	parsedURL.RawFragment = ""
+	if !strings.HasSuffix(parsedURL.Path, "/") {
+		parsedURL.Path += "/"
+		if parsedURL.RawPath != "" {
+			parsedURL.RawPath += "/"
+		}
+	}
+	return parsedURL, nil
+}
+
 // WithAPIKey sets the API key to be used for requests.
 func WithAPIKey(apiKey string) ClientOption {
 	return func(c *Client) error {
@@ -103,6 +219,48 @@ func WithAPIKey(apiKey string) ClientOption {
 	}
 }
 
+// WithTransport sets the RoundTripper sending the requests, e.g. to wrap the default transport
+// with tracing or metrics. The API key set with WithAPIKey is still added to the requests.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) error {
+		if rt == nil {
+			return errors.New("transport must not be nil")
+		}
+
+		if auth, ok := c.client.Transport.(*authTransport); ok {
+			auth.rt = rt
+			return nil
+		}
+
+		c.client.Transport = rt
+		return nil
+	}
+}
+
+// WithRequestInterceptor adds a function called with every request after it's built and before
+// it's sent, e.g. to inject tracing headers or custom authentication. The interceptors are called
+// in the order they were added, and the first error returned aborts the request.
+func WithRequestInterceptor(interceptor RequestInterceptor) ClientOption {
+	return func(c *Client) error {
+		c.interceptors = append(c.interceptors, interceptor)
+		return nil
+	}
+}
+
+// WithTimeout sets a timeout for every request whose context has no deadline, except the logs
+// streams. A request timing out returns an *Error of type ErrorTypeTimeout, wrapping the
+// context.DeadlineExceeded error.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		if timeout <= 0 {
+			return fmt.Errorf("invalid timeout %s: must be positive", timeout)
+		}
+
+		c.timeout = timeout
+		return nil
+	}
+}
+
 // WithDebug enables debug mode.
 func WithDebug() ClientOption {
 	return func(c *Client) error {
@@ -111,6 +269,40 @@ func WithDebug() ClientOption {
 	}
 }
 
+// WithRequestRecorder sets a function that receives the dumps of every request and its response,
+// with the API key redacted. It's useful to attach the exchanged messages to bug reports.
+func WithRequestRecorder(recorder RequestRecorder) ClientOption {
+	return func(c *Client) error {
+		c.recorder = recorder
+		return nil
+	}
+}
+
+// WithAcceptLanguage sets the Accept-Language header sent with every request, so the names
+// localized by the API (countries, categories, ...) are returned in the given language.
+func WithAcceptLanguage(language string) ClientOption {
+	return func(c *Client) error {
+		c.acceptLanguage = language
+		return nil
+	}
+}
+
+// WithUserAgent prepends the product of the application to the User-Agent header sent with
+// every request, such as "myapp/1.2.3 nextdns-go", so its traffic can be identified. An empty
+// product keeps the default User-Agent.
+func WithUserAgent(product string) ClientOption {
+	return func(c *Client) error {
+		product = strings.TrimSpace(product)
+		if product == "" {
+			c.userAgent = userAgent
+			return nil
+		}
+
+		c.userAgent = product + " " + userAgent
+		return nil
+	}
+}
+
 // WithHTTPClient sets a custom HTTP client that can be used for requests.
 func WithHTTPClient(client *http.Client) ClientOption {
 	return func(c *Client) error {
@@ -131,8 +323,11 @@ func New(opts ...ClientOption) (*Client, error) {
 	}
 
 	c := &Client{
-		client:  cleanhttp.DefaultClient(),
-		baseURL: baseURL,
+		client:      cleanhttp.DefaultClient(),
+		baseURL:     baseURL,
+		rateLimiter: newRateLimiter(),
+		stats:       &stats{},
+		userAgent:   userAgent,
 	}
 
 	for _, opt := range opts {
@@ -142,6 +337,9 @@ func New(opts ...ClientOption) (*Client, error) {
 		}
 	}
 
+	// Initialize the service for the Account.
+	c.Account = NewAccountService(c)
+
 	// Initialize the services for the Profile.
 	c.Profiles = NewProfilesService(c)
 
@@ -185,19 +383,144 @@ func New(opts ...ClientOption) (*Client, error) {
 	return c, nil
 }
 
+// NewWithKey instantiates a new NextDNS client authenticated with the given API key. The key is
+// applied after the other options, so it also authenticates a client set with WithHTTPClient.
+func NewWithKey(apiKey string, opts ...ClientOption) (*Client, error) {
+	if strings.TrimSpace(apiKey) == "" {
+		return nil, ErrEmptyAPIToken
+	}
+
+	// The options are copied, so the spare capacity of the slice of the caller is never written.
+	return New(append(opts[:len(opts):len(opts)], WithAPIKey(apiKey))...)
+}
+
+// serviceClient is the client of a service. It shares the *Client of every service, so a change
+// to the client (e.g. Debug) applies to all of them, and only overrides the base URL of the
+// requests when the service has one configured.
+type serviceClient struct {
+	*Client
+
+	// baseURL overrides the base URL of the client, if set.
+	baseURL *url.URL
+}
+
+// newServiceClient returns the client of a service, with its base URL overridden if configured.
+func newServiceClient(client *Client, service ServiceName) *serviceClient {
+	return &serviceClient{
+		Client:  client,
+		baseURL: client.serviceBaseURLs[service],
+	}
+}
+
+// base returns the base URL of the requests of the service.
+func (c *serviceClient) base() *url.URL {
+	if c.baseURL != nil {
+		return c.baseURL
+	}
+	return c.Client.baseURL
+}
+
+// newRequest creates a new HTTP request to the base URL of the service.
+func (c *serviceClient) newRequest(method string, path string, body interface{}) (*http.Request, error) {
+	return c.newRequestAt(c.base(), method, path, nil, body)
+}
+
+// newRequestWithQuery creates a new HTTP request with query parameters to the base URL of the service.
+func (c *serviceClient) newRequestWithQuery(method string, path string, query url.Values, body interface{}) (*http.Request, error) {
+	return c.newRequestAt(c.base(), method, path, query, body)
+}
+
 // do executes an HTTP request and decodes the response into v.
 func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) error {
+	// The deadline of a context derived with the client timeout can only be exceeded by the timeout.
+	_, hasDeadline := ctx.Deadline()
+	withTimeout := !hasDeadline && c.timeout > 0
+	if withTimeout {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
 	req = req.WithContext(ctx)
+	if err := c.intercept(req); err != nil {
+		return err
+	}
+
+	var reqDump []byte
+	if c.recorder != nil {
+		dump, err := httputil.DumpRequestOut(req, true)
+		if err != nil {
+			return err
+		}
+		reqDump = apiKeyHeaderRegexp.ReplaceAll(dump, []byte("X-Api-Key: [REDACTED]\r"))
+	}
 
-	res, err := c.client.Do(req)
+	start := time.Now()
+	res, err := c.send(ctx, req)
+	latency := time.Since(start)
 	if err != nil {
+		c.stats.record(latency, true)
+		if logger := c.requestLogger(); logger != nil {
+			logger.Logf("%s %s failed after %s: %v%s", req.Method, req.URL.Redacted(), latency, err, c.profileLabel(req.URL))
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			e := newContextError(ctxErr, err)
+			if withTimeout && e.Type == ErrorTypeTimeout {
+				e.Meta["timeout"] = c.timeout.String()
+			}
+			return e
+		}
 		return err
 	}
 	defer func() { _ = res.Body.Close() }()
+	c.stats.record(latency, res.StatusCode >= http.StatusBadRequest)
+	if logger := c.requestLogger(); logger != nil {
+		logger.Logf("%s %s %d %s%s", req.Method, req.URL.Redacted(), res.StatusCode, latency, c.profileLabel(req.URL))
+	}
+
+	if c.recorder != nil {
+		respDump, err := httputil.DumpResponse(res, true)
+		if err != nil {
+			return err
+		}
+		c.recorder(reqDump, respDump)
+	}
 
 	return c.handleResponse(res, v)
 }
 
+// doStream executes an HTTP request whose response body is consumed incrementally, like a
+// server-sent events stream. The caller must close the body of the returned response.
+func (c *Client) doStream(ctx context.Context, req *http.Request) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "text/event-stream")
+	if err := c.intercept(req); err != nil {
+		return nil, err
+	}
+
+	res, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		defer func() { _ = res.Body.Close() }()
+		return nil, c.handleResponse(res, nil)
+	}
+
+	return res, nil
+}
+
+// intercept calls the request interceptors with a request about to be sent.
+func (c *Client) intercept(req *http.Request) error {
+	for _, interceptor := range c.interceptors {
+		if err := interceptor(req); err != nil {
+			return fmt.Errorf("error intercepting the request %s %s: %w", req.Method, req.URL.Redacted(), err)
+		}
+	}
+	return nil
+}
+
 // handleResponse handles the response from the NextDNS API and decodes the response into v if provided.
 // The goal is to handle the common errors that can occur when making a request to the NextDNS API,
 // and also provide custom error responses for the client.
@@ -209,9 +532,9 @@ func (c *Client) handleResponse(res *http.Response, v interface{}) error {
 
 	if c.Debug {
 		if string(out) == "" {
-			fmt.Printf("[DEBUG] RESPONSE: StatusCode:%d\n", res.StatusCode)
+			c.debugf("RESPONSE: StatusCode:%d", res.StatusCode)
 		} else {
-			fmt.Printf("[DEBUG] RESPONSE: StatusCode:%d, Body:%v\n", res.StatusCode, string(out))
+			c.debugf("RESPONSE: StatusCode:%d, Body:%v", res.StatusCode, string(out))
 		}
 	}
 
@@ -238,6 +561,25 @@ func (c *Client) handleResponse(res *http.Response, v interface{}) error {
 			}
 		}
 
+		// Rate limit responses aren't guaranteed to have an error body: the API errors are
+		// included only when the body can be decoded.
+		if res.StatusCode == http.StatusTooManyRequests {
+			if delay, ok := parseRetryAfter(res.Header.Get(retryAfterHeader), time.Now()); ok {
+				meta["retry-after"] = delay.String()
+			}
+
+			errorRes := &ErrorResponse{}
+			if json.Unmarshal(out, errorRes) != nil || len(errorRes.Errors) == 0 {
+				errorRes = nil
+			}
+			return &Error{
+				Type:    ErrorTypeRateLimit,
+				Message: errRateLimitError,
+				Errors:  errorRes,
+				Meta:    meta,
+			}
+		}
+
 		// Tries to handle the error response body from the NextDNS API,
 		// encapsulated in a client error.
 		errorRes := &ErrorResponse{}
@@ -277,8 +619,9 @@ func (c *Client) handleResponse(res *http.Response, v interface{}) error {
 		}
 	}
 
-	// Returns if there is no object to decode.
-	if v == nil {
+	// Returns if there is no object to decode, or no content to decode it from,
+	// like the empty responses of some update endpoints.
+	if v == nil || len(bytes.TrimSpace(out)) == 0 {
 		return nil
 	}
 
@@ -303,52 +646,17 @@ func (c *Client) handleResponse(res *http.Response, v interface{}) error {
 
 // newRequest creates a new HTTP request.
 func (c *Client) newRequest(method string, path string, body interface{}) (*http.Request, error) {
-	u, err := c.baseURL.Parse(path)
-	if err != nil {
-		return nil, err
-	}
-
-	var req *http.Request
-	switch method {
-	case http.MethodGet:
-		if c.Debug {
-			fmt.Printf("[DEBUG] REQUEST: Method:%s, URL:%s\n", method, u.String())
-		}
-		req, err = http.NewRequest(method, u.String(), nil)
-		if err != nil {
-			return nil, err
-		}
-	default:
-		buf := new(bytes.Buffer)
-		if body != nil {
-			err = json.NewEncoder(buf).Encode(body)
-			if err != nil {
-				return nil, err
-			}
-		}
-		if c.Debug {
-			if buf.String() == "" {
-				fmt.Printf("[DEBUG] REQUEST: Method:%s, URL:%s\n", method, u.String())
-			} else {
-				fmt.Printf("[DEBUG] REQUEST: Method:%s, URL:%s, Body:%s\n", method, u.String(), strings.TrimSuffix(buf.String(), "\n"))
-			}
-		}
-		req, err = http.NewRequest(method, u.String(), buf)
-		if err != nil {
-			return nil, err
-		}
-
-		req.Header.Set("Content-Type", contentType)
-	}
-
-	req.Header.Set("Accept", contentType)
-	req.Header.Set("User-Agent", userAgent)
-	return req, nil
+	return c.newRequestAt(c.baseURL, method, path, nil, body)
 }
 
 // newRequestWithQuery creates a new HTTP request with query parameters.
 func (c *Client) newRequestWithQuery(method string, path string, query url.Values, body interface{}) (*http.Request, error) {
-	u, err := c.baseURL.Parse(path)
+	return c.newRequestAt(c.baseURL, method, path, query, body)
+}
+
+// newRequestAt creates a new HTTP request with query parameters, relative to the given base URL.
+func (c *Client) newRequestAt(baseURL *url.URL, method string, path string, query url.Values, body interface{}) (*http.Request, error) {
+	u, err := baseURL.Parse(path)
 	if err != nil {
 		return nil, err
 	}
@@ -361,7 +669,7 @@ func (c *Client) newRequestWithQuery(method string, path string, query url.Value
 	switch method {
 	case http.MethodGet:
 		if c.Debug {
-			fmt.Printf("[DEBUG] REQUEST: Method:%s, URL:%s\n", method, u.String())
+			c.debugf("REQUEST: Method:%s, URL:%s", method, u.String())
 		}
 		req, err = http.NewRequest(method, u.String(), nil)
 		if err != nil {
@@ -377,9 +685,9 @@ func (c *Client) newRequestWithQuery(method string, path string, query url.Value
 		}
 		if c.Debug {
 			if buf.String() == "" {
-				fmt.Printf("[DEBUG] REQUEST: Method:%s, URL:%s\n", method, u.String())
+				c.debugf("REQUEST: Method:%s, URL:%s", method, u.String())
 			} else {
-				fmt.Printf("[DEBUG] REQUEST: Method:%s, URL:%s, Body:%s\n", method, u.String(), strings.TrimSuffix(buf.String(), "\n"))
+				c.debugf("REQUEST: Method:%s, URL:%s, Body:%s", method, u.String(), strings.TrimSuffix(buf.String(), "\n"))
 			}
 		}
 		req, err = http.NewRequest(method, u.String(), buf)
@@ -391,7 +699,10 @@ func (c *Client) newRequestWithQuery(method string, path string, query url.Value
 	}
 
 	req.Header.Set("Accept", contentType)
-	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.acceptLanguage != "" {
+		req.Header.Set("Accept-Language", c.acceptLanguage)
+	}
 	return req, nil
 }
 
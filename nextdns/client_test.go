@@ -1,8 +1,18 @@
 package nextdns
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/matryer/is"
 )
@@ -35,3 +45,419 @@ func TestNewRequestWithQueryEmpty(t *testing.T) {
 
 	c.Equal(req.URL.String(), "https://api.nextdns.io/profiles/abc123/analytics/status")
 }
+
+func TestWithRequestRecorder(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Header.Get("X-Api-Key"), "secret-key")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": {"name": "My Profile"}}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	var calls int
+	var reqDump, respDump []byte
+	recorder := func(req, resp []byte) {
+		calls++
+		reqDump = req
+		respDump = resp
+	}
+
+	client, err := New(WithBaseURL(ts.URL), WithAPIKey("secret-key"), WithRequestRecorder(recorder))
+	c.NoErr(err)
+
+	req, err := client.newRequest(http.MethodGet, "profiles/abc123", nil)
+	c.NoErr(err)
+	req.Header.Set("X-Api-Key", "secret-key")
+
+	profile := profileResponse{}
+	err = client.do(context.Background(), req, &profile)
+	c.NoErr(err)
+
+	c.Equal(calls, 1)
+	c.True(strings.HasPrefix(string(reqDump), "GET /profiles/abc123 HTTP/1.1"))
+	c.True(!strings.Contains(string(reqDump), "secret-key"))
+	c.True(strings.Contains(string(reqDump), "X-Api-Key: [REDACTED]"))
+	c.True(strings.HasPrefix(string(respDump), "HTTP/1.1 200 OK"))
+	c.True(strings.Contains(string(respDump), `{"data": {"name": "My Profile"}}`))
+	c.Equal(profile.Profile.Name, "My Profile")
+}
+
+func TestWithServiceBaseURL(t *testing.T) {
+	c := is.New(t)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.URL.Path, "/profiles/abc123")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": {"name": "My Profile"}}`))
+		c.NoErr(err)
+	}))
+	defer api.Close()
+
+	fake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.URL.Path, "/profiles/abc123/logs")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": [{"domain": "fake.com"}], "meta": {"pagination": {"cursor": ""}}}`))
+		c.NoErr(err)
+	}))
+	defer fake.Close()
+
+	client, err := New(WithBaseURL(api.URL), WithServiceBaseURL(ServiceLogs, fake.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	logs, err := client.Logs.Get(ctx, &GetLogsRequest{ProfileID: "abc123"})
+	c.NoErr(err)
+	c.Equal(logs.Data[0].Domain, "fake.com")
+
+	profile, err := client.Profiles.Get(ctx, &GetProfileRequest{ProfileID: "abc123"})
+	c.NoErr(err)
+	c.Equal(profile.Name, "My Profile")
+}
+
+func TestUpdateEmptyDataResponses(t *testing.T) {
+	c := is.New(t)
+
+	for _, body := range []string{`{"data":{}}`, ``} {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c.Equal(r.Method, "PATCH")
+
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(body))
+			c.NoErr(err)
+		}))
+
+		client, err := New(WithBaseURL(ts.URL))
+		c.NoErr(err)
+
+		ctx := context.Background()
+		err = client.Settings.Update(ctx, &UpdateSettingsRequest{ProfileID: "abc123", Settings: &Settings{Web3: true}})
+		c.NoErr(err)
+
+		err = client.Security.Update(ctx, &UpdateSecurityRequest{ProfileID: "abc123", Security: &Security{Csam: true}})
+		c.NoErr(err)
+
+		err = client.Profiles.Update(ctx, &UpdateProfileRequest{ProfileID: "abc123", Profile: &Profile{Name: "renamed"}})
+		c.NoErr(err)
+
+		active := true
+		err = client.PrivacyNatives.Update(ctx, &UpdatePrivacyNativesRequest{ProfileID: "abc123", NativeID: "apple", Active: &active})
+		c.NoErr(err)
+
+		ts.Close()
+	}
+}
+
+func TestHandleResponseEmptyBody(t *testing.T) {
+	c := is.New(t)
+
+	client, err := New()
+	c.NoErr(err)
+
+	res := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(" \n"))}
+	response := settingsResponse{}
+	err = client.handleResponse(res, &response)
+	c.NoErr(err)
+	c.True(response.Settings == nil)
+}
+
+func TestWithAcceptLanguage(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Header.Get("Accept-Language"), "fr-FR")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": []}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL), WithAcceptLanguage("fr-FR"))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	_, err = client.Analytics.GetDestinations(ctx, &GetAnalyticsDestinationsRequest{ProfileID: "abc123", Type: "countries"})
+	c.NoErr(err)
+
+	req, err := client.newRequest(http.MethodPatch, "profiles/abc123", nil)
+	c.NoErr(err)
+	c.Equal(req.Header.Get("Accept-Language"), "fr-FR")
+
+	client, err = New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	req, err = client.newRequest(http.MethodGet, "profiles", nil)
+	c.NoErr(err)
+	c.Equal(req.Header.Get("Accept-Language"), "")
+}
+
+func TestWithUserAgent(t *testing.T) {
+	c := is.New(t)
+
+	var got string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": []}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		opts []ClientOption
+		want string
+	}{
+		{opts: nil, want: "nextdns-go"},
+		{opts: []ClientOption{WithUserAgent("myapp/1.2.3")}, want: "myapp/1.2.3 nextdns-go"},
+		{opts: []ClientOption{WithUserAgent(" ")}, want: "nextdns-go"},
+	}
+
+	for _, tt := range tests {
+		client, err := New(append([]ClientOption{WithBaseURL(ts.URL)}, tt.opts...)...)
+		c.NoErr(err)
+
+		_, err = client.Profiles.List(context.Background(), &ListProfileRequest{})
+		c.NoErr(err)
+		c.Equal(got, tt.want)
+
+		_, err = client.Analytics.GetStatus(context.Background(), &GetAnalyticsRequest{ProfileID: "abc123"})
+		c.NoErr(err)
+		c.Equal(got, tt.want)
+	}
+}
+
+func TestWithBaseURLPathPrefix(t *testing.T) {
+	c := is.New(t)
+
+	tests := []struct {
+		baseURL string
+		want    string
+	}{
+		{baseURL: "https://proxy/nextdns/", want: "https://proxy/nextdns/profiles"},
+		{baseURL: "https://proxy/nextdns", want: "https://proxy/nextdns/profiles"},
+		{baseURL: "https://proxy/nextdns/?token=abc#section", want: "https://proxy/nextdns/profiles"},
+		{baseURL: "https://api.nextdns.io", want: "https://api.nextdns.io/profiles"},
+	}
+
+	for _, tt := range tests {
+		client, err := New(WithBaseURL(tt.baseURL))
+		c.NoErr(err)
+
+		req, err := client.newRequest(http.MethodGet, profilesAPIPath, nil)
+		c.NoErr(err)
+		c.Equal(req.URL.String(), tt.want)
+
+		req, err = client.newRequestWithQuery(http.MethodGet, profileAPIPath("abc123"), url.Values{"limit": {"10"}}, nil)
+		c.NoErr(err)
+		c.Equal(req.URL.String(), strings.TrimSuffix(tt.want, "profiles")+"profiles/abc123?limit=10")
+	}
+}
+
+func TestNewWithKey(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Header.Get("X-Api-Key"), "key123")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": []}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := NewWithKey("key123", WithBaseURL(ts.URL), WithHTTPClient(&http.Client{Transport: http.DefaultTransport}))
+	c.NoErr(err)
+
+	_, err = client.Profiles.List(context.Background(), &ListProfileRequest{})
+	c.NoErr(err)
+}
+
+func TestNewWithKeyOptionsNotModified(t *testing.T) {
+	c := is.New(t)
+
+	opts := make([]ClientOption, 1, 2)
+	opts[0] = WithBaseURL("http://localhost")
+	sentinel := ClientOption(func(*Client) error { return nil })
+	spare := append(opts, sentinel)
+
+	_, err := NewWithKey("secret-key", opts...)
+	c.NoErr(err)
+	c.True(reflect.ValueOf(spare[1]).Pointer() == reflect.ValueOf(sentinel).Pointer())
+}
+
+func TestNewWithKeyEmpty(t *testing.T) {
+	c := is.New(t)
+
+	for _, key := range []string{"", "  "} {
+		client, err := NewWithKey(key)
+		c.Equal(err, ErrEmptyAPIToken)
+		c.Equal(client, nil)
+	}
+}
+
+func TestWithRequestInterceptor(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Header.Get("Traceparent"), "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+		c.Equal(r.Header.Get("X-Api-Key"), "key123")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": []}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(
+		WithBaseURL(ts.URL),
+		WithAPIKey("key123"),
+		WithRequestInterceptor(func(req *http.Request) error {
+			req.Header.Set("Traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+			return nil
+		}),
+	)
+	c.NoErr(err)
+
+	_, err = client.Profiles.List(context.Background(), &ListProfileRequest{})
+	c.NoErr(err)
+}
+
+func TestWithRequestInterceptorError(t *testing.T) {
+	c := is.New(t)
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	errNoToken := errors.New("no token")
+	client, err := New(WithBaseURL(ts.URL), WithRequestInterceptor(func(*http.Request) error {
+		return errNoToken
+	}))
+	c.NoErr(err)
+
+	_, err = client.Profiles.List(context.Background(), &ListProfileRequest{})
+	c.True(errors.Is(err, errNoToken))
+	c.True(strings.Contains(err.Error(), "error intercepting the request GET "))
+	c.Equal(calls, 0)
+}
+
+// headerTransport is a RoundTripper adding a header to the requests.
+type headerTransport struct {
+	rt http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.Header.Set("X-Transport", "custom")
+	return t.rt.RoundTrip(req)
+}
+
+func TestWithTransport(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Header.Get("X-Transport"), "custom")
+		c.Equal(r.Header.Get("X-Api-Key"), "key123")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": []}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(
+		WithBaseURL(ts.URL),
+		WithAPIKey("key123"),
+		WithTransport(&headerTransport{rt: http.DefaultTransport}),
+	)
+	c.NoErr(err)
+
+	_, err = client.Profiles.List(context.Background(), &ListProfileRequest{})
+	c.NoErr(err)
+}
+
+func TestWithTimeout(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(100 * time.Millisecond):
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": []}`))
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL), WithTimeout(20*time.Millisecond))
+	c.NoErr(err)
+
+	_, err = client.Profiles.List(context.Background(), &ListProfileRequest{})
+	c.True(errors.Is(err, context.DeadlineExceeded))
+
+	var e *Error
+	c.True(errors.As(err, &e))
+	c.Equal(e.Type, ErrorTypeTimeout)
+
+	// The deadline of the caller takes precedence over the client timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err = client.Profiles.List(ctx, &ListProfileRequest{})
+	c.NoErr(err)
+	c.True(time.Since(start) >= 100*time.Millisecond)
+}
+
+func TestWithTimeoutInvalid(t *testing.T) {
+	c := is.New(t)
+
+	_, err := New(WithTimeout(0))
+	c.True(err != nil)
+}
+
+func TestWithServiceBaseURLSharedClient(t *testing.T) {
+	c := is.New(t)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.URL.Path, "/profiles/abc123/settings/logs")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": {"enabled": true, "retention": 7776000}}`))
+		c.NoErr(err)
+	}))
+	defer api.Close()
+
+	fake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.URL.Path, "/profiles")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": [{"id": "abc123", "name": "My Profile"}], "meta": {"pagination": {"cursor": ""}}}`))
+		c.NoErr(err)
+	}))
+	defer fake.Close()
+
+	var out bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&out)
+
+	client, err := New(WithBaseURL(api.URL), WithServiceBaseURL(ServiceProfiles, fake.URL))
+	c.NoErr(err)
+	client.Debug = true
+
+	ids, err := client.Profiles.AuditRetention(context.Background(), 30)
+	c.NoErr(err)
+	c.Equal(ids, []string{"abc123"})
+
+	c.True(strings.Contains(out.String(), "GET "+fake.URL+"/profiles 200 "))
+	c.True(strings.Contains(out.String(), "GET "+api.URL+"/profiles/abc123/settings/logs 200 "))
+}
@@ -0,0 +1,57 @@
+package nextdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// forEachConcurrent calls fn with the index and the value of each ID, with at most limit calls in
+// flight. Once the context is canceled, no new call is started. The returned errors are aligned
+// with the IDs: nil for the calls that succeeded, and the context error for the IDs skipped
+// because of the cancellation.
+func forEachConcurrent(ctx context.Context, ids []string, limit int, fn func(i int, id string) error) []error {
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(ids))
+		sem  = make(chan struct{}, limit)
+	)
+
+	for i, id := range ids {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			<-sem
+			errs[i] = err
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			errs[i] = fn(i, id)
+		}(i, id)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// joinIDErrors joins the errors returned by forEachConcurrent, each prefixed by the kind and the
+// value of its ID (e.g. "profile abc123: ...").
+func joinIDErrors(kind string, ids []string, errs []error) error {
+	var joined []error
+	for i, err := range errs {
+		if err != nil {
+			joined = append(joined, fmt.Errorf("%s %s: %w", kind, ids[i], err))
+		}
+	}
+	return errors.Join(joined...)
+}
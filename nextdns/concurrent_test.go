@@ -0,0 +1,53 @@
+package nextdns
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestForEachConcurrent(t *testing.T) {
+	c := is.New(t)
+
+	var (
+		mu       sync.Mutex
+		inFlight int
+		peak     int
+	)
+	ids := []string{"a", "b", "c", "d", "e", "f"}
+	results := make([]string, len(ids))
+	errs := forEachConcurrent(context.Background(), ids, 2, func(i int, id string) error {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		results[i] = id
+		if id == "c" {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	c.Equal(results, ids)
+	c.True(peak <= 2)
+	c.Equal(joinIDErrors("profile", ids, errs).Error(), "profile c: failed")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	errs = forEachConcurrent(ctx, ids, 2, func(_ int, _ string) error {
+		c.Fail() // No call is started once the context is canceled.
+		return nil
+	})
+	for _, err := range errs {
+		c.True(errors.Is(err, context.Canceled))
+	}
+}
@@ -0,0 +1,16 @@
+package nextdns
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestAnalyticsEntryCountryName(t *testing.T) {
+	c := is.New(t)
+
+	c.Equal((&AnalyticsEntry{ID: "US"}).CountryName(), "United States")
+	c.Equal((&AnalyticsEntry{ID: "DE"}).CountryName(), "Germany")
+	c.Equal((&AnalyticsEntry{ID: "XZ"}).CountryName(), "XZ")
+	c.Equal((&AnalyticsEntry{ID: "amazon"}).CountryName(), "amazon")
+}
@@ -2,8 +2,13 @@ package nextdns
 
 import (
 	"context"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 // denylistAPIPath is the HTTP path for the denylist API.
@@ -12,6 +17,7 @@ const denylistAPIPath = "denylist"
 // Denylist represents the denylist of a profile.
 type Denylist struct {
 	ID     string `json:"id,omitempty"`
+	Name   string `json:"name,omitempty"` // Optional user-set label of the entry.
 	Active bool   `json:"active"`
 }
 
@@ -53,16 +59,19 @@ type DenylistService interface {
 	Update(context.Context, *UpdateDenylistRequest) error
 	Delete(context.Context, *DeleteDenylistRequest) error
 	Add(context.Context, *AddDenylistRequest) error
+	Search(ctx context.Context, profileID string, query string) ([]*Denylist, error)
+	ImportCSV(ctx context.Context, profileID string, r io.Reader) error
 }
 
 // denylistResponse represents the denylist response.
 type denylistResponse struct {
 	Denylist []*Denylist `json:"data"`
+	listMeta
 }
 
 // denylistService represents the NextDNS denylist service.
 type denylistService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ DenylistService = &denylistService{}
@@ -71,7 +80,7 @@ var _ DenylistService = &denylistService{}
 // nolint: revive
 func NewDenylistService(client *Client) *denylistService {
 	return &denylistService{
-		client: client,
+		client: newServiceClient(client, ServiceDenylist),
 	}
 }
 
@@ -85,7 +94,11 @@ func (s *denylistService) Create(ctx context.Context, request *CreateDenylistReq
 
 	err = s.client.do(ctx, req, nil)
 	if err != nil {
-		return fmt.Errorf("error making a request to create an deny list: %w", err)
+		ids := make([]string, len(request.Denylist))
+		for i, entry := range request.Denylist {
+			ids[i] = entry.ID
+		}
+		return fmt.Errorf("error making a request to create an deny list: %w", newBulkError(err, ids))
 	}
 
 	return nil
@@ -147,7 +160,7 @@ func (s *denylistService) Add(ctx context.Context, request *AddDenylistRequest)
 		ID     string `json:"id"`
 		Active *bool  `json:"active,omitempty"`
 	}{
-		ID:     request.ID,
+		ID:     NormalizeDomain(request.ID),
 		Active: request.Active,
 	}
 	req, err := s.client.newRequest(http.MethodPost, path, body)
@@ -163,7 +176,80 @@ func (s *denylistService) Add(ctx context.Context, request *AddDenylistRequest)
 	return nil
 }
 
+// Search returns the deny list entries whose ID contains the query, ignoring case.
+// The NextDNS API doesn't support searching the deny list, so the entries are filtered client-side
+// after listing the whole deny list.
+func (s *denylistService) Search(ctx context.Context, profileID string, query string) ([]*Denylist, error) {
+	list, err := s.List(ctx, &ListDenylistRequest{ProfileID: profileID})
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	results := make([]*Denylist, 0)
+	for _, entry := range list {
+		if strings.Contains(strings.ToLower(entry.ID), query) {
+			results = append(results, entry)
+		}
+	}
+
+	return results, nil
+}
+
+// ImportCSV replaces the denylist of a profile with the entries read from CSV rows of the
+// "domain,active" form. The header row is optional, and an entry is active when the active
+// column is missing or empty. An input without any entry is rejected, as it would empty the
+// denylist.
+func (s *denylistService) ImportCSV(ctx context.Context, profileID string, r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	denylist := []*Denylist{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error reading the deny list csv: %w", err)
+		}
+
+		domain := NormalizeDomain(record[0])
+		if line == 1 && domain == "domain" {
+			continue
+		}
+		if domain == "" {
+			return fmt.Errorf("error reading the deny list csv: empty domain on line %d", line)
+		}
+
+		active := true
+		if len(record) > 1 && strings.TrimSpace(record[1]) != "" {
+			active, err = strconv.ParseBool(strings.TrimSpace(record[1]))
+			if err != nil {
+				return fmt.Errorf("error reading the deny list csv: invalid active value %q on line %d", record[1], line)
+			}
+		}
+
+		denylist = append(denylist, &Denylist{ID: domain, Active: active})
+	}
+
+	// An empty import would silently delete the whole denylist of the profile.
+	if len(denylist) == 0 {
+		return &Error{
+			Type:    ErrorTypeRequest,
+			Message: "the deny list csv has no entries: refusing to empty the deny list",
+		}
+	}
+
+	return s.Create(ctx, &CreateDenylistRequest{
+		ProfileID: profileID,
+		Denylist:  denylist,
+	})
+}
+
 // denylistIDAPIPath returns the HTTP path for the denylist API.
+// The ID is path-escaped, so wildcard entries like "*.ads.com" are safely encoded.
 func denylistIDAPIPath(id string) string {
-	return fmt.Sprintf("%s/%s", denylistAPIPath, id)
+	return fmt.Sprintf("%s/%s", denylistAPIPath, pathSegment(id))
 }
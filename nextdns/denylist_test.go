@@ -2,8 +2,12 @@ package nextdns
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/matryer/is"
@@ -76,11 +80,17 @@ func TestDenylistGet(t *testing.T) {
 func TestDenylistUpdate(t *testing.T) {
 	c := is.New(t)
 
-	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusNoContent)
-		_, err := w.Write([]byte(""))
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PATCH")
+		c.Equal(r.URL.Path, "/profiles/abc123/denylist/apple.com")
+
+		body, err := io.ReadAll(r.Body)
 		c.NoErr(err)
+		c.Equal(string(body), `{"active":true}`+"\n")
+
+		w.WriteHeader(http.StatusNoContent)
 	}))
+	defer ts.Close()
 
 	client, err := New(WithBaseURL(ts.URL))
 	c.NoErr(err)
@@ -151,3 +161,160 @@ func TestDenylistAdd(t *testing.T) {
 
 	c.NoErr(err)
 }
+
+func TestDenylistAddWildcard(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "POST")
+		c.Equal(r.URL.Path, "/profiles/abc123/denylist")
+
+		body, err := io.ReadAll(r.Body)
+		c.NoErr(err)
+		c.Equal(string(body), "{\"id\":\"*.ads.com\"}\n")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{"data": {"id": "*.ads.com"}}`
+		_, err = w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	err = client.Denylist.Add(ctx, &AddDenylistRequest{
+		ProfileID: "abc123",
+		ID:        " *.Ads.com. ",
+	})
+
+	c.NoErr(err)
+}
+
+func TestDenylistDeleteWildcardPathEncoding(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "DELETE")
+		c.Equal(r.URL.EscapedPath(), "/profiles/abc123/denylist/%2A.ads.com")
+		c.Equal(r.URL.Path, "/profiles/abc123/denylist/*.ads.com")
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	err = client.Denylist.Delete(ctx, &DeleteDenylistRequest{
+		ProfileID: "abc123",
+		ID:        "*.ads.com",
+	})
+
+	c.NoErr(err)
+}
+
+func TestDenylistSearch(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/denylist")
+
+		w.WriteHeader(http.StatusOK)
+		out := `{"data":[{"id":"ads.google.com","active":true},{"id":"apple.com","active":false},{"id":"*.googlesyndication.com","active":true}]}`
+		_, err := w.Write([]byte(out))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	results, err := client.Denylist.Search(ctx, "abc123", "Google")
+
+	c.NoErr(err)
+	c.Equal(results, []*Denylist{
+		{ID: "ads.google.com", Active: true},
+		{ID: "*.googlesyndication.com", Active: true},
+	})
+}
+
+func TestDenylistUnmarshalName(t *testing.T) {
+	c := is.New(t)
+
+	var response denylistResponse
+	err := json.Unmarshal([]byte(`{"data":[{"id":"ads.com","name":"Ad network","active":true},{"id":"apple.com","active":false}]}`), &response)
+	c.NoErr(err)
+	c.Equal(response.Denylist[0].Name, "Ad network")
+	c.Equal(response.Denylist[1].Name, "")
+
+	out, err := json.Marshal(response.Denylist)
+	c.NoErr(err)
+	c.Equal(string(out), `[{"id":"ads.com","name":"Ad network","active":true},{"id":"apple.com","active":false}]`)
+}
+
+func TestDenylistImportCSV(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PUT")
+		c.Equal(r.URL.Path, "/profiles/abc123/denylist")
+
+		var body []*Denylist
+		c.NoErr(json.NewDecoder(r.Body).Decode(&body))
+		c.Equal(body, []*Denylist{
+			{ID: "ads.example.com", Active: true},
+			{ID: "tracker.example.com", Active: false},
+			{ID: "malware.example.com", Active: true},
+			{ID: "*.doubleclick.net", Active: true},
+		})
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	csv := "domain,active\nads.example.com,true\nTracker.Example.com, false\nmalware.example.com,\n*.doubleclick.net\n"
+	err = client.Denylist.ImportCSV(context.Background(), "abc123", strings.NewReader(csv))
+	c.NoErr(err)
+}
+
+func TestDenylistImportCSVInvalid(t *testing.T) {
+	c := is.New(t)
+
+	client, err := New(WithBaseURL("http://127.0.0.1:0"))
+	c.NoErr(err)
+
+	err = client.Denylist.ImportCSV(context.Background(), "abc123", strings.NewReader("ads.example.com,maybe\n"))
+	c.True(err != nil)
+	c.True(strings.Contains(err.Error(), "line 1"))
+}
+
+func TestDenylistImportCSVEmpty(t *testing.T) {
+	c := is.New(t)
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	for _, input := range []string{"", "domain,active\n"} {
+		err = client.Denylist.ImportCSV(context.Background(), "abc123", strings.NewReader(input))
+
+		var e *Error
+		c.True(errors.As(err, &e))
+		c.Equal(e.Type, ErrorTypeRequest)
+	}
+	c.Equal(calls, 0)
+}
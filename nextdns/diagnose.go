@@ -0,0 +1,95 @@
+package nextdns
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Diagnostics represents the result of each step of the connection to the NextDNS API. The steps
+// after a failed one are skipped and left to their zero value.
+type Diagnostics struct {
+	Host string // Host of the base URL.
+
+	Addresses []string      // Addresses the host resolved to.
+	DNSLookup time.Duration // Duration of the DNS resolution.
+
+	Connect time.Duration // Duration of the TCP connection to the first address.
+
+	TLS          bool          // Whether the TLS handshake succeeded. Always false for plain HTTP base URLs.
+	TLSHandshake time.Duration // Duration of the TLS handshake.
+
+	Health *HealthStatus // Result of a test authenticated request.
+}
+
+// Diagnose checks each step of the connection to the NextDNS API, to troubleshoot setups behind
+// firewalls or proxies: the DNS resolution of the base host, the TCP connection, the TLS handshake,
+// and a test authenticated request. The DNS, TCP and TLS steps are made directly, without the
+// proxy of the HTTP client. The diagnostics collected so far are returned along with the error
+// of the failed step.
+func (c *Client) Diagnose(ctx context.Context) (*Diagnostics, error) {
+	host := c.baseURL.Hostname()
+	port := c.baseURL.Port()
+	if port == "" {
+		port = "443"
+		if c.baseURL.Scheme == "http" {
+			port = "80"
+		}
+	}
+
+	diagnostics := &Diagnostics{Host: host}
+
+	start := time.Now()
+	addresses, err := net.DefaultResolver.LookupHost(ctx, host)
+	diagnostics.DNSLookup = time.Since(start)
+	if err != nil {
+		return diagnostics, fmt.Errorf("error resolving %s: %w", host, err)
+	}
+	diagnostics.Addresses = addresses
+
+	dialer := &net.Dialer{}
+	start = time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(addresses[0], port))
+	diagnostics.Connect = time.Since(start)
+	if err != nil {
+		return diagnostics, fmt.Errorf("error connecting to %s: %w", host, err)
+	}
+	defer conn.Close()
+
+	if c.baseURL.Scheme == "https" {
+		config := c.tlsConfig()
+		config.ServerName = host
+
+		tlsConn := tls.Client(conn, config)
+		start = time.Now()
+		err = tlsConn.HandshakeContext(ctx)
+		diagnostics.TLSHandshake = time.Since(start)
+		if err != nil {
+			return diagnostics, fmt.Errorf("error making the TLS handshake with %s: %w", host, err)
+		}
+		diagnostics.TLS = true
+	}
+
+	health, err := c.Health(ctx)
+	diagnostics.Health = health
+	if err != nil {
+		return diagnostics, err
+	}
+
+	return diagnostics, nil
+}
+
+// tlsConfig returns a copy of the TLS configuration of the HTTP client transport, if any.
+func (c *Client) tlsConfig() *tls.Config {
+	rt := c.client.Transport
+	if auth, ok := rt.(*authTransport); ok {
+		rt = auth.rt
+	}
+	if transport, ok := rt.(*http.Transport); ok && transport.TLSClientConfig != nil {
+		return transport.TLSClientConfig.Clone()
+	}
+	return &tls.Config{}
+}
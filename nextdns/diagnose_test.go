@@ -0,0 +1,55 @@
+package nextdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestDiagnose(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.URL.Path, "/profiles")
+		c.Equal(r.Header.Get("X-Api-Key"), "abc")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": []}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL), WithHTTPClient(ts.Client()), WithAPIKey("abc"))
+	c.NoErr(err)
+
+	diagnostics, err := client.Diagnose(context.Background())
+	c.NoErr(err)
+	c.Equal(diagnostics.Host, "127.0.0.1")
+	c.Equal(diagnostics.Addresses, []string{"127.0.0.1"})
+	c.True(diagnostics.Connect > 0)
+	c.True(diagnostics.TLS)
+	c.True(diagnostics.TLSHandshake > 0)
+	c.True(diagnostics.Health.Authenticated)
+	c.True(diagnostics.Health.Latency > 0)
+}
+
+func TestDiagnosePartialFailure(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	diagnostics, err := client.Diagnose(context.Background())
+	c.True(err != nil)
+	c.Equal(diagnostics.Addresses, []string{"127.0.0.1"})
+	c.True(!diagnostics.TLS)
+	c.Equal(diagnostics.Health, nil)
+}
@@ -0,0 +1,19 @@
+package nextdns
+
+import "strings"
+
+// wildcardPrefix is the prefix used by list entries matching all the subdomains of a domain.
+const wildcardPrefix = "*."
+
+// IsWildcard reports whether the domain is a wildcard entry (e.g. "*.ads.com").
+func IsWildcard(domain string) bool {
+	domain = NormalizeDomain(domain)
+	return strings.HasPrefix(domain, wildcardPrefix) && len(domain) > len(wildcardPrefix)
+}
+
+// NormalizeDomain normalizes a denylist or allowlist entry, lowering its case and
+// removing surrounding spaces and the trailing dot. Wildcard entries are preserved.
+func NormalizeDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	return strings.TrimSuffix(domain, ".")
+}
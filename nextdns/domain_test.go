@@ -0,0 +1,24 @@
+package nextdns
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestIsWildcard(t *testing.T) {
+	c := is.New(t)
+
+	c.True(IsWildcard("*.ads.com"))
+	c.True(IsWildcard(" *.ADS.com. "))
+	c.True(!IsWildcard("ads.com"))
+	c.True(!IsWildcard("*."))
+	c.True(!IsWildcard("ads.*.com"))
+}
+
+func TestNormalizeDomain(t *testing.T) {
+	c := is.New(t)
+
+	c.Equal(NormalizeDomain(" Ads.COM. "), "ads.com")
+	c.Equal(NormalizeDomain("*.Ads.com"), "*.ads.com")
+}
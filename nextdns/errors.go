@@ -1,9 +1,14 @@
 package nextdns
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // ErrorType defines the code of an error.
@@ -12,11 +17,20 @@ type ErrorType string
 // ErrEmptyAPIToken is returned when an empty API token is provided during client initialization.
 var ErrEmptyAPIToken = errors.New("api key must not be empty")
 
+// ErrInvalidDestinationsType is returned when the destinations analytics type is empty or not supported.
+var ErrInvalidDestinationsType = errors.New("destinations type must be \"countries\" or \"gafam\"")
+
+// ErrInvalidSortOrder is returned when the logs sort order is neither "asc" nor "desc".
+var ErrInvalidSortOrder = errors.New("sort order must be \"asc\" or \"desc\"")
+
 const (
 	errInternalServiceError = "internal service error received"
 	errResponseError        = "response error received"
 	errMalformedError       = "malformed response body received"
 	errMalformedErrorBody   = "malformed error response body received"
+	errRateLimitError       = "rate limit exceeded"
+	errTimeoutError         = "request timed out"
+	errCanceledError        = "request canceled"
 )
 
 // ErrorType constants classify errors returned by the NextDNS Client.
@@ -26,6 +40,9 @@ const (
 	ErrorTypeMalformed      ErrorType = "malformed"      // Response body is malformed.
 	ErrorTypeAuthentication ErrorType = "authentication" // Authentication error.
 	ErrorTypeNotFound       ErrorType = "not_found"      // Resource not found.
+	ErrorTypeRateLimit      ErrorType = "rate_limit"     // Too many requests.
+	ErrorTypeTimeout        ErrorType = "timeout"        // Request timed out.
+	ErrorTypeCanceled       ErrorType = "canceled"       // Request canceled.
 )
 
 // ErrorResponse represents the error response from the NextDNS API.
@@ -45,6 +62,9 @@ type Error struct {
 	Message string
 	Errors  *ErrorResponse
 	Meta    map[string]string
+
+	// Err is the underlying error of the client errors not returned by the API, like timeouts.
+	Err error
 }
 
 // APIError represents a single error from the NextDNS API.
@@ -68,6 +88,16 @@ func (e *APIError) Error() string {
 	return e.Code
 }
 
+// Field returns the name of the field the error refers to, which is the last segment of a
+// nested parameter path (e.g. "retention" for "settings.logs.retention").
+// The full path is preserved in Parameter.
+func (e *APIError) Field() string {
+	if i := strings.LastIndex(e.Parameter, "."); i >= 0 {
+		return e.Parameter[i+1:]
+	}
+	return e.Parameter
+}
+
 // Is reports whether the error matches the target by comparing error codes.
 func (e *APIError) Is(target error) bool {
 	t, ok := target.(*APIError)
@@ -98,28 +128,68 @@ func (e *Error) Error() string {
 			}
 		}
 	}
+	if e.Err != nil {
+		out.WriteString(": ")
+		out.WriteString(e.Err.Error())
+	}
 
 	return out.String()
 }
 
-// Unwrap returns the underlying API errors for use with errors.Is and errors.As.
-// Returns nil if there are no underlying API errors.
+// Unwrap returns the underlying API errors, and the underlying error if set, for use with
+// errors.Is and errors.As. Returns nil if there are no underlying errors.
 func (e *Error) Unwrap() []error {
-	if e.Errors == nil || len(e.Errors.Errors) == 0 {
-		return nil
-	}
-
-	errs := make([]error, len(e.Errors.Errors))
-	for i, apiErr := range e.Errors.Errors {
-		errs[i] = &APIError{
-			Code:      apiErr.Code,
-			Detail:    apiErr.Detail,
-			Parameter: apiErr.Source.Parameter,
+	var errs []error
+	if e.Errors != nil {
+		for _, apiErr := range e.Errors.Errors {
+			errs = append(errs, &APIError{
+				Code:      apiErr.Code,
+				Detail:    apiErr.Detail,
+				Parameter: apiErr.Source.Parameter,
+			})
 		}
 	}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
 	return errs
 }
 
+// newContextError returns the client error of a request interrupted by its context, wrapping the
+// request error so errors.Is still matches context.Canceled or context.DeadlineExceeded.
+func newContextError(ctxErr error, err error) *Error {
+	if !errors.Is(err, ctxErr) {
+		err = fmt.Errorf("%w: %w", ctxErr, err)
+	}
+
+	e := &Error{
+		Type:    ErrorTypeCanceled,
+		Message: errCanceledError,
+		Meta:    map[string]string{},
+		Err:     err,
+	}
+	if errors.Is(ctxErr, context.DeadlineExceeded) {
+		e.Type = ErrorTypeTimeout
+		e.Message = errTimeoutError
+	}
+	return e
+}
+
+// RetryAfter returns how long to wait before retrying the request, as reported by the
+// Retry-After header of a rate limit response. It returns false if the header wasn't set.
+func (e *Error) RetryAfter() (time.Duration, bool) {
+	value, ok := e.Meta["retry-after"]
+	if !ok {
+		return 0, false
+	}
+
+	delay, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, false
+	}
+	return delay, true
+}
+
 // IsNotFound returns true if the error is a not found error.
 func IsNotFound(err error) bool {
 	var e *Error
@@ -155,3 +225,80 @@ func HasErrorCode(err error, code string) bool {
 	}
 	return false
 }
+
+// BulkError represents a failed bulk request where the NextDNS API rejected some of the entries.
+// Entries contains the API error of each rejected entry, keyed by the entry ID.
+type BulkError struct {
+	Entries map[string]*APIError
+	Err     error
+}
+
+// Error returns the string representation of the bulk error.
+func (e *BulkError) Error() string {
+	ids := make([]string, 0, len(e.Entries))
+	for id := range e.Entries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return fmt.Sprintf("%s (rejected entries: %s)", e.Err.Error(), strings.Join(ids, ", "))
+}
+
+// Unwrap returns the underlying client error.
+func (e *BulkError) Unwrap() error {
+	return e.Err
+}
+
+// bulkErrorResponse represents an error response with the source pointer of each rejected entry.
+type bulkErrorResponse struct {
+	Errors []struct {
+		Code   string `json:"code"`
+		Detail string `json:"detail,omitempty"`
+		Source struct {
+			Parameter string `json:"parameter,omitempty"`
+			Pointer   string `json:"pointer,omitempty"`
+		} `json:"source,omitempty"`
+	} `json:"errors"`
+}
+
+// newBulkError maps the per-entry errors of a bulk request to the IDs of the submitted entries.
+// It returns the original error when the response doesn't reference any entry.
+func newBulkError(err error, ids []string) error {
+	var e *Error
+	if !errors.As(err, &e) || e.Errors == nil {
+		return err
+	}
+
+	res := bulkErrorResponse{}
+	if json.Unmarshal([]byte(e.Meta["body"]), &res) != nil {
+		return err
+	}
+
+	entries := make(map[string]*APIError)
+	for _, apiErr := range res.Errors {
+		index, ok := entryIndex(apiErr.Source.Pointer)
+		if !ok || index >= len(ids) {
+			continue
+		}
+		entries[ids[index]] = &APIError{
+			Code:      apiErr.Code,
+			Detail:    apiErr.Detail,
+			Parameter: apiErr.Source.Parameter,
+		}
+	}
+
+	if len(entries) == 0 {
+		return err
+	}
+	return &BulkError{Entries: entries, Err: err}
+}
+
+// entryIndex returns the index of the entry referenced by a JSON pointer (e.g. "/1/id").
+func entryIndex(pointer string) (int, bool) {
+	for _, segment := range strings.Split(pointer, "/") {
+		index, err := strconv.Atoi(segment)
+		if err == nil && index >= 0 {
+			return index, true
+		}
+	}
+	return 0, false
+}
@@ -1,8 +1,12 @@
 package nextdns
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/matryer/is"
 )
@@ -299,3 +303,125 @@ func TestHasErrorCode(t *testing.T) {
 	c.True(HasErrorCode(err, "duplicate"))
 	c.True(!HasErrorCode(err, "notFound"))
 }
+
+func TestEntryIndex(t *testing.T) {
+	c := is.New(t)
+
+	index, ok := entryIndex("/1/id")
+	c.True(ok)
+	c.Equal(index, 1)
+
+	index, ok = entryIndex("/data/3")
+	c.True(ok)
+	c.Equal(index, 3)
+
+	_, ok = entryIndex("/name")
+	c.True(!ok)
+}
+
+func TestAPIError_Field(t *testing.T) {
+	c := is.New(t)
+
+	c.Equal((&APIError{Parameter: "settings.logs.retention"}).Field(), "retention")
+	c.Equal((&APIError{Parameter: "name"}).Field(), "name")
+	c.Equal((&APIError{}).Field(), "")
+}
+
+func TestError_RetryAfter(t *testing.T) {
+	c := is.New(t)
+
+	tests := []struct {
+		header string
+		delay  time.Duration
+	}{
+		{header: "30", delay: 30 * time.Second},
+		{header: time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), delay: time.Hour},
+		{header: "Wed, 21 Oct 2015 07:28:00 GMT", delay: 0},
+	}
+
+	for _, tt := range tests {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Retry-After", tt.header)
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+
+		client, err := New(WithBaseURL(ts.URL))
+		c.NoErr(err)
+
+		_, err = client.Profiles.List(context.Background(), &ListProfileRequest{})
+		ts.Close()
+
+		var e *Error
+		c.True(errors.As(err, &e))
+		c.Equal(e.Type, ErrorTypeRateLimit)
+
+		delay, ok := e.RetryAfter()
+		c.True(ok)
+		c.True(delay <= tt.delay && delay > tt.delay-time.Minute)
+	}
+}
+
+func TestError_RetryAfter_NotSet(t *testing.T) {
+	c := is.New(t)
+
+	err := &Error{Type: ErrorTypeRateLimit, Meta: map[string]string{}}
+	_, ok := err.RetryAfter()
+	c.True(!ok)
+}
+
+func TestContextCanceledError(t *testing.T) {
+	c := is.New(t)
+
+	started := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	// The context is canceled before the request.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = client.Profiles.List(ctx, &ListProfileRequest{})
+	c.True(errors.Is(err, context.Canceled))
+	var e *Error
+	c.True(errors.As(err, &e))
+	c.Equal(e.Type, ErrorTypeCanceled)
+
+	// The context is canceled during the request.
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	_, err = client.Profiles.List(ctx, &ListProfileRequest{})
+	c.True(errors.Is(err, context.Canceled))
+	c.True(errors.As(err, &e))
+	c.Equal(e.Type, ErrorTypeCanceled)
+}
+
+func TestContextDeadlineExceededError(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Profiles.List(ctx, &ListProfileRequest{})
+	c.True(errors.Is(err, context.DeadlineExceeded))
+	var e *Error
+	c.True(errors.As(err, &e))
+	c.Equal(e.Type, ErrorTypeTimeout)
+}
@@ -0,0 +1,48 @@
+package nextdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HealthStatus represents the health of the path between the client and the NextDNS API.
+type HealthStatus struct {
+	Reachable     bool          // Whether the NextDNS API answered the request.
+	Authenticated bool          // Whether the API key was accepted.
+	Latency       time.Duration // Round-trip time of the health request.
+}
+
+// Health checks the reachability and authentication of the NextDNS API by making a single
+// lightweight authenticated request. An authentication failure is reported in the returned
+// status and is not considered an error.
+func (c *Client) Health(ctx context.Context) (*HealthStatus, error) {
+	req, err := c.newRequest(http.MethodGet, profilesAPIPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to check the health: %w", err)
+	}
+
+	status := &HealthStatus{}
+	start := time.Now()
+	err = c.do(ctx, req, nil)
+	status.Latency = time.Since(start)
+
+	if err != nil {
+		if IsAuthError(err) {
+			status.Reachable = true
+			return status, nil
+		}
+
+		// The timeouts and cancellations are reported as an *Error too, but the API never
+		// answered the request.
+		var e *Error
+		status.Reachable = errors.As(err, &e) && e.Type != ErrorTypeTimeout && e.Type != ErrorTypeCanceled
+		return status, fmt.Errorf("error making a request to check the health: %w", err)
+	}
+
+	status.Reachable = true
+	status.Authenticated = true
+	return status, nil
+}
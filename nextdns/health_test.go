@@ -0,0 +1,94 @@
+package nextdns
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestHealthHealthy(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles")
+		c.Equal(r.Header.Get("X-Api-Key"), "abc")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": [], "meta": {"pagination": {"cursor": ""}}}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL), WithAPIKey("abc"))
+	c.NoErr(err)
+
+	status, err := client.Health(context.Background())
+	c.NoErr(err)
+	c.True(status.Reachable)
+	c.True(status.Authenticated)
+	c.True(status.Latency > 0)
+}
+
+func TestHealthAuthFailed(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, err := w.Write([]byte(`{"errors": [{"code": "forbidden"}]}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL), WithAPIKey("wrong"))
+	c.NoErr(err)
+
+	status, err := client.Health(context.Background())
+	c.NoErr(err)
+	c.True(status.Reachable)
+	c.True(!status.Authenticated)
+}
+
+func TestHealthUnreachable(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+	ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	status, err := client.Health(context.Background())
+	c.True(err != nil)
+	c.True(!status.Reachable)
+	c.True(!status.Authenticated)
+}
+
+func TestHealthTimeout(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	status, err := client.Health(ctx)
+	c.True(errors.Is(err, context.DeadlineExceeded))
+	c.True(!status.Reachable)
+	c.True(!status.Authenticated)
+}
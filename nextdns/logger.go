@@ -0,0 +1,86 @@
+package nextdns
+
+import (
+	"log"
+	"net/url"
+	"strings"
+)
+
+// Logger receives the log lines of the requests sent by the client.
+type Logger interface {
+	Logf(format string, args ...any)
+}
+
+// stdLogger is a Logger writing to the standard logger of the log package.
+type stdLogger struct{}
+
+// Logf writes a log line with the standard logger.
+func (stdLogger) Logf(format string, args ...any) {
+	log.Printf(format, args...)
+}
+
+// WithLogger sets a logger receiving the method, URL, status code and duration of every request,
+// labeled with the profile ID of the profile-scoped requests.
+// The headers aren't logged, so the API key is never written to the logs. Without a logger, the
+// requests are logged with the standard log package when the debug mode is enabled. The debug
+// dumps of the requests and responses are written to the same logger.
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Client) error {
+		c.logger = logger
+		return nil
+	}
+}
+
+// requestLogger returns the logger receiving the log lines of the requests: the logger set with
+// WithLogger, or the standard logger when the debug mode is enabled. It's chosen on every call,
+// so enabling Debug after New takes effect.
+func (c *Client) requestLogger() Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+	if c.Debug {
+		return stdLogger{}
+	}
+	return nil
+}
+
+// debugf writes a debug line to the request logger, when the debug mode is enabled.
+func (c *Client) debugf(format string, args ...any) {
+	if c.Debug {
+		c.requestLogger().Logf("[DEBUG] "+format, args...)
+	}
+}
+
+// profileLabel returns the label of the profile targeted by a request, relative to the base URL
+// of the service the request was sent to.
+func (c *Client) profileLabel(u *url.URL) string {
+	for _, baseURL := range c.serviceBaseURLs {
+		if baseURL.Host == u.Host {
+			if label := logProfileLabel(baseURL, u); label != "" {
+				return label
+			}
+		}
+	}
+	return logProfileLabel(c.baseURL, u)
+}
+
+// logProfileLabel returns the label of the profile targeted by a request, parsed from its path
+// relative to the base URL, or an empty string for the requests not scoped to a profile.
+func logProfileLabel(baseURL *url.URL, u *url.URL) string {
+	path, ok := strings.CutPrefix(u.EscapedPath(), baseURL.EscapedPath())
+	if !ok {
+		return ""
+	}
+
+	path, ok = strings.CutPrefix(path, profilesAPIPath+"/")
+	if !ok {
+		return ""
+	}
+
+	segment, _, _ := strings.Cut(path, "/")
+	profileID, err := url.PathUnescape(segment)
+	if err != nil || profileID == "" {
+		return ""
+	}
+	return " [profile " + profileID + "]"
+}
@@ -0,0 +1,139 @@
+package nextdns
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// fakeLogger records the log lines.
+type fakeLogger struct {
+	lines []string
+}
+
+func (l *fakeLogger) Logf(format string, args ...any) {
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func TestWithLogger(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": []}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	logger := &fakeLogger{}
+	client, err := New(WithBaseURL(ts.URL), WithAPIKey("secret-key"), WithLogger(logger))
+	c.NoErr(err)
+
+	_, err = client.Profiles.List(context.Background(), &ListProfileRequest{})
+	c.NoErr(err)
+
+	c.Equal(len(logger.lines), 1)
+	c.True(strings.HasPrefix(logger.lines[0], "GET "+ts.URL+"/profiles 200 "))
+	c.True(!strings.Contains(logger.lines[0], "secret-key"))
+}
+
+func TestWithDebugDefaultLogger(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	var out bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&out)
+
+	client, err := New(WithBaseURL(ts.URL), WithDebug())
+	c.NoErr(err)
+
+	err = client.Profiles.Delete(context.Background(), &DeleteProfileRequest{ProfileID: "abc123"})
+	c.NoErr(err)
+
+	c.True(strings.Contains(out.String(), "DELETE "+ts.URL+"/profiles/abc123 204 "))
+	c.True(strings.Contains(out.String(), "[DEBUG] REQUEST: Method:DELETE, URL:"+ts.URL+"/profiles/abc123"))
+	c.True(strings.Contains(out.String(), "[DEBUG] RESPONSE: StatusCode:204"))
+}
+
+func TestWithDebugCustomLogger(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	logger := &fakeLogger{}
+	client, err := New(WithBaseURL(ts.URL), WithDebug(), WithLogger(logger))
+	c.NoErr(err)
+
+	err = client.Profiles.Delete(context.Background(), &DeleteProfileRequest{ProfileID: "abc123"})
+	c.NoErr(err)
+
+	// The debug dumps and the request line all go to the logger, once each.
+	c.Equal(len(logger.lines), 3)
+	c.Equal(logger.lines[0], "[DEBUG] REQUEST: Method:DELETE, URL:"+ts.URL+"/profiles/abc123")
+	c.True(strings.HasPrefix(logger.lines[1], "DELETE "+ts.URL+"/profiles/abc123 204 "))
+	c.Equal(logger.lines[2], "[DEBUG] RESPONSE: StatusCode:204")
+}
+
+func TestWithLoggerProfileLabel(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": {"name": "Home"}}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	logger := &fakeLogger{}
+	client, err := New(WithBaseURL(ts.URL+"/proxy"), WithLogger(logger))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	_, err = client.Settings.Get(ctx, &GetSettingsRequest{ProfileID: "abc123"})
+	c.NoErr(err)
+	_, err = client.Account.Get(ctx)
+	c.NoErr(err)
+
+	c.Equal(len(logger.lines), 2)
+	c.True(strings.HasPrefix(logger.lines[0], "GET "+ts.URL+"/proxy/profiles/abc123/settings 200 "))
+	c.True(strings.HasSuffix(logger.lines[0], " [profile abc123]"))
+	c.True(!strings.Contains(logger.lines[1], "[profile"))
+}
+
+func TestDebugAfterNew(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	var out bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&out)
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+	client.Debug = true
+
+	err = client.Profiles.Delete(context.Background(), &DeleteProfileRequest{ProfileID: "abc123"})
+	c.NoErr(err)
+
+	c.True(strings.Contains(out.String(), "[DEBUG] REQUEST: Method:DELETE, URL:"+ts.URL+"/profiles/abc123"))
+	c.True(strings.Contains(out.String(), "DELETE "+ts.URL+"/profiles/abc123 204 "))
+}
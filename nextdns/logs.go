@@ -3,9 +3,11 @@ package nextdns
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -40,17 +42,76 @@ type LogEntry struct {
 	Reasons   []LogReason `json:"reasons,omitempty"`
 }
 
+// Explain returns a human-friendly description of the action taken for the query,
+// e.g. "blocked by EasyList", "allowed by Allowlist" or "default resolved".
+func (e *LogEntry) Explain() string {
+	reasons := make([]string, 0, len(e.Reasons))
+	for _, reason := range e.Reasons {
+		if reason.Name != "" {
+			reasons = append(reasons, reason.Name)
+		} else {
+			reasons = append(reasons, reason.ID)
+		}
+	}
+
+	switch e.Status {
+	case "blocked", "allowed":
+		if len(reasons) == 0 {
+			return e.Status
+		}
+		return fmt.Sprintf("%s by %s", e.Status, strings.Join(reasons, ", "))
+	case "error":
+		return "resolution error"
+	default:
+		return "default resolved"
+	}
+}
+
+// FilterLogs returns the log entries matching the predicate, in the same order. The NextDNS API
+// can't filter the logs by encryption or protocol, so these filters must be applied client-side:
+//
+//	unencrypted := nextdns.FilterLogs(response.Data, func(e *nextdns.LogEntry) bool {
+//		return !e.Encrypted
+//	})
+func FilterLogs(entries []*LogEntry, predicate func(*LogEntry) bool) []*LogEntry {
+	filtered := make([]*LogEntry, 0, len(entries))
+	for _, entry := range entries {
+		if predicate(entry) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// SortOrder is the order of the log entries.
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"  // Oldest entries first.
+	SortOrderDesc SortOrder = "desc" // Newest entries first.
+)
+
+// validate checks the sort order is supported by the API, an empty value meaning the default.
+func (o SortOrder) validate() error {
+	switch o {
+	case "", SortOrderAsc, SortOrderDesc:
+		return nil
+	default:
+		return fmt.Errorf("%w: got %q", ErrInvalidSortOrder, string(o))
+	}
+}
+
 // LogsQueryOptions contains parameters for querying logs.
 type LogsQueryOptions struct {
-	From   string // Date filter (ISO 8601, Unix timestamp, or relative like "-7d")
-	To     string // Date filter
-	Sort   string // "asc" or "desc" (default: "desc")
-	Limit  int    // Results per page (10-1000, default 100)
-	Cursor string // Pagination cursor
-	Device string // Filter by device ID
-	Status string // Filter: "default", "error", "blocked", "allowed"
-	Search string // Domain search (partial matching supported)
-	Raw    bool   // Show all queries vs. cleaned navigational only
+	From   string    // Date filter (ISO 8601, Unix timestamp, or relative like "-7d")
+	To     string    // Date filter
+	Sort   SortOrder // SortOrderAsc or SortOrderDesc (default: SortOrderDesc)
+	Limit  int       // Results per page (10-1000, default 100)
+	Cursor string    // Pagination cursor
+	Device string    // Filter by device ID
+	Status string    // Filter: "default", "error", "blocked", "allowed"
+	Search string    // Domain search (partial matching supported)
+	Raw    bool      // Show all queries vs. cleaned navigational only
 }
 
 // LogsPagination contains cursor for pagination.
@@ -99,10 +160,26 @@ type LogsService interface {
 
 	// Clear deletes all logs for a profile.
 	Clear(ctx context.Context, request *ClearLogsRequest) error
+
+	// Iterate returns an iterator over the log entries, fetching the pages as needed with the
+	// context passed to Next.
+	Iterate(request *GetLogsRequest) *LogsIterator
+
+	// GetAll queries the log entries of every page, up to maxEntries entries.
+	GetAll(ctx context.Context, request *GetLogsRequest, maxEntries int) ([]*LogEntry, error)
+
+	// GetSince queries the logs newer than a timestamp, oldest first.
+	GetSince(ctx context.Context, profileID string, since time.Time, opts *LogsQueryOptions) (*LogsResponse, error)
+
+	// StreamTo writes the logs streamed in real time to w, as NDJSON or CSV lines.
+	StreamTo(ctx context.Context, request *StreamLogsRequest, w io.Writer, format string) error
+
+	// Stream streams the logs in real time, resuming the stream on transient disconnects.
+	Stream(ctx context.Context, request *StreamLogsRequest) (<-chan *LogEntry, <-chan error, error)
 }
 
 type logsService struct {
-	client *Client
+	client *serviceClient
 }
 
 // Compile-time check that logsService implements LogsService.
@@ -112,52 +189,87 @@ var _ LogsService = &logsService{}
 // nolint: revive
 func NewLogsService(client *Client) *logsService {
 	return &logsService{
-		client: client,
+		client: newServiceClient(client, ServiceLogs),
 	}
 }
 
 // buildLogsQuery converts LogsQueryOptions to url.Values.
 func buildLogsQuery(opts *LogsQueryOptions) url.Values {
-	query := url.Values{}
+	query := newQueryBuilder()
 	if opts == nil {
-		return query
-	}
-	if opts.From != "" {
-		query.Set("from", opts.From)
+		return query.SetString("sort", string(SortOrderDesc)).Values()
 	}
-	if opts.To != "" {
-		query.Set("to", opts.To)
+	sort := opts.Sort
+	if sort == "" {
+		sort = SortOrderDesc
 	}
-	if opts.Sort != "" {
-		query.Set("sort", opts.Sort)
-	}
-	if opts.Limit > 0 {
-		query.Set("limit", strconv.Itoa(opts.Limit))
-	}
-	if opts.Cursor != "" {
-		query.Set("cursor", opts.Cursor)
+	return query.
+		SetString("from", opts.From).
+		SetString("to", opts.To).
+		SetString("sort", string(sort)).
+		SetInt("limit", opts.Limit).
+		SetString("cursor", opts.Cursor).
+		SetString("device", opts.Device).
+		SetString("status", opts.Status).
+		SetString("search", opts.Search).
+		SetBool("raw", opts.Raw).
+		Values()
+}
+
+// ParseLogsQuery builds LogsQueryOptions from URL query parameters, using the same parameter
+// names as the NextDNS API. It's useful to proxy dashboard requests to the logs endpoint.
+func ParseLogsQuery(query url.Values) (*LogsQueryOptions, error) {
+	opts := &LogsQueryOptions{
+		From:   query.Get("from"),
+		To:     query.Get("to"),
+		Sort:   SortOrder(query.Get("sort")),
+		Cursor: query.Get("cursor"),
+		Device: query.Get("device"),
+		Status: query.Get("status"),
+		Search: query.Get("search"),
 	}
-	if opts.Device != "" {
-		query.Set("device", opts.Device)
+
+	if err := opts.Sort.validate(); err != nil {
+		return nil, err
 	}
-	if opts.Status != "" {
-		query.Set("status", opts.Status)
+
+	switch opts.Status {
+	case "", "default", "error", "blocked", "allowed":
+	default:
+		return nil, fmt.Errorf("invalid logs status %q: must be \"default\", \"error\", \"blocked\" or \"allowed\"", opts.Status)
 	}
-	if opts.Search != "" {
-		query.Set("search", opts.Search)
+
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 10 || value > 1000 {
+			return nil, fmt.Errorf("invalid logs limit %q: must be an integer between 10 and 1000", limit)
+		}
+		opts.Limit = value
 	}
-	if opts.Raw {
-		query.Set("raw", "true")
+
+	if raw := query.Get("raw"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid logs raw %q: must be a boolean", raw)
+		}
+		opts.Raw = value
 	}
-	return query
+
+	return opts, nil
 }
 
 func logsPath(profileID string) string {
-	return fmt.Sprintf("%s/%s/%s", profilesAPIPath, profileID, logsAPIPath)
+	return fmt.Sprintf("%s/%s", profileAPIPath(profileID), logsAPIPath)
 }
 
 // Get queries DNS query logs with filtering and pagination.
 func (s *logsService) Get(ctx context.Context, request *GetLogsRequest) (*LogsResponse, error) {
+	if request.Options != nil {
+		if err := request.Options.Sort.validate(); err != nil {
+			return nil, fmt.Errorf("error validating request to get logs: %w", err)
+		}
+	}
+
 	path := logsPath(request.ProfileID)
 	query := buildLogsQuery(request.Options)
 
@@ -184,6 +296,42 @@ func (s *logsService) Get(ctx context.Context, request *GetLogsRequest) (*LogsRe
 	}, nil
 }
 
+// GetAll queries the log entries of every page, walking through them with Iterate until the
+// last page or until maxEntries entries are collected. A maxEntries of 0 or less means no limit.
+// When ctx is canceled mid-pagination, the entries collected so far are returned alongside
+// ctx.Err(); on any other error no entries are returned.
+func (s *logsService) GetAll(ctx context.Context, request *GetLogsRequest, maxEntries int) ([]*LogEntry, error) {
+	var entries []*LogEntry
+	it := s.Iterate(request)
+	for (maxEntries <= 0 || len(entries) < maxEntries) && it.Next(ctx) {
+		entries = append(entries, it.Entry())
+	}
+	if err := it.Err(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return entries, ctxErr
+		}
+		return nil, fmt.Errorf("error getting all the logs: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetSince queries the logs newer than a timestamp, sorted from the oldest to the newest.
+// It's the common pattern to poll for new entries; the other options are preserved.
+func (s *logsService) GetSince(ctx context.Context, profileID string, since time.Time, opts *LogsQueryOptions) (*LogsResponse, error) {
+	options := &LogsQueryOptions{}
+	if opts != nil {
+		*options = *opts
+	}
+	options.From = since.UTC().Format(time.RFC3339Nano)
+	options.Sort = SortOrderAsc
+
+	return s.Get(ctx, &GetLogsRequest{
+		ProfileID: profileID,
+		Options:   options,
+	})
+}
+
 // Clear deletes all logs for a profile.
 func (s *logsService) Clear(ctx context.Context, request *ClearLogsRequest) error {
 	path := logsPath(request.ProfileID)
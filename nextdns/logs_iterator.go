@@ -0,0 +1,60 @@
+package nextdns
+
+import "context"
+
+// LogsIterator walks through the log entries of a profile, fetching the next page when the
+// current one is exhausted.
+//
+//	it := client.Logs.Iterate(&nextdns.GetLogsRequest{ProfileID: "abc123"})
+//	for it.Next(ctx) {
+//		entry := it.Entry()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type LogsIterator struct {
+	pages *pageIterator[*LogEntry]
+}
+
+// Next advances to the next entry, fetching the next page if needed. It returns false when there
+// are no more entries or an error occurred, which is then returned by Err.
+func (it *LogsIterator) Next(ctx context.Context) bool {
+	return it.pages.next(ctx)
+}
+
+// Entry returns the current entry.
+func (it *LogsIterator) Entry() *LogEntry {
+	return it.pages.entry()
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *LogsIterator) Err() error {
+	return it.pages.err
+}
+
+// Iterate returns an iterator over the log entries matching the request, in the order and with
+// the page size of its options. The request is copied, and only the cursor of the copy changes
+// between the pages. No request is made until the first call to Next, so the context is given to
+// Next rather than here: each page is fetched with the context of the call that needs it.
+func (s *logsService) Iterate(request *GetLogsRequest) *LogsIterator {
+	options := &LogsQueryOptions{}
+	if request.Options != nil {
+		*options = *request.Options
+	}
+	req := &GetLogsRequest{
+		ProfileID: request.ProfileID,
+		Options:   options,
+	}
+
+	return &LogsIterator{
+		pages: newPageIterator(options.Cursor, func(ctx context.Context, cursor string) ([]*LogEntry, string, error) {
+			req.Options.Cursor = cursor
+			response, err := s.Get(ctx, req)
+			if err != nil {
+				return nil, "", err
+			}
+			return response.Data, response.Pagination.Cursor, nil
+		}),
+	}
+}
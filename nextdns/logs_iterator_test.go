@@ -0,0 +1,103 @@
+package nextdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestLogsIterate(t *testing.T) {
+	c := is.New(t)
+
+	pages := map[string]string{
+		"":      `{"data": [{"domain": "a.example.com"}, {"domain": "b.example.com"}], "meta": {"pagination": {"cursor": "page2"}, "stream": {"id": "s1"}}}`,
+		"page2": `{"data": [{"domain": "c.example.com"}], "meta": {"pagination": {"cursor": null}, "stream": {"id": "s1"}}}`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.URL.Path, "/profiles/abc123/logs")
+		c.Equal(r.URL.Query().Get("sort"), "asc")
+		c.Equal(r.URL.Query().Get("limit"), "2")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(pages[r.URL.Query().Get("cursor")]))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	request := &GetLogsRequest{
+		ProfileID: "abc123",
+		Options:   &LogsQueryOptions{Sort: SortOrderAsc, Limit: 2},
+	}
+	it := client.Logs.Iterate(request)
+
+	ctx := context.Background()
+	var domains []string
+	for it.Next(ctx) {
+		domains = append(domains, it.Entry().Domain)
+	}
+
+	c.NoErr(it.Err())
+	c.Equal(domains, []string{"a.example.com", "b.example.com", "c.example.com"})
+	c.Equal(request.Options.Cursor, "") // the request of the caller is not modified
+}
+
+func TestLogsIterateError(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, err := w.Write([]byte(`{"errors": [{"code": "forbidden"}]}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	it := client.Logs.Iterate(&GetLogsRequest{ProfileID: "abc123"})
+
+	c.True(!it.Next(context.Background()))
+	c.True(IsAuthError(it.Err()))
+	c.Equal(it.Entry(), nil)
+}
+
+func TestLogsIterateStableCursor(t *testing.T) {
+	c := is.New(t)
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		// The first page points to a page which keeps returning its own cursor.
+		resp := `{"data": [{"domain": "a.example.com"}], "meta": {"pagination": {"cursor": "same"}}}`
+		if r.URL.Query().Get("cursor") == "same" {
+			resp = `{"data": [], "meta": {"pagination": {"cursor": "same"}}}`
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	it := client.Logs.Iterate(&GetLogsRequest{ProfileID: "abc123"})
+
+	ctx := context.Background()
+	var domains []string
+	for it.Next(ctx) {
+		domains = append(domains, it.Entry().Domain)
+	}
+
+	c.NoErr(it.Err())
+	c.Equal(domains, []string{"a.example.com"})
+	c.Equal(calls, 2)
+}
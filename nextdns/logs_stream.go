@@ -0,0 +1,297 @@
+package nextdns
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logsStreamAPIPath is the HTTP path for the logs streaming API.
+const logsStreamAPIPath = "logs/stream"
+
+// Log export formats supported by StreamTo.
+const (
+	LogsFormatNDJSON = "ndjson" // One JSON object per line.
+	LogsFormatCSV    = "csv"    // Comma-separated values with a header line.
+)
+
+// logsCSVHeader is the header line of the CSV log export.
+var logsCSVHeader = []string{"timestamp", "domain", "root", "tracker", "encrypted", "protocol", "clientIp", "client", "device", "status", "reasons"}
+
+// StreamLogsRequest is used for streaming logs in real time.
+type StreamLogsRequest struct {
+	ProfileID string
+	ID        string // Stream ID to resume from (see LogsResponse.Stream)
+	Device    string // Filter by device ID
+	Status    string // Filter: "default", "error", "blocked", "allowed"
+	Search    string // Domain search (partial matching supported)
+	Raw       bool   // Show all queries vs. cleaned navigational only
+}
+
+// buildLogsStreamQuery converts StreamLogsRequest to url.Values.
+func buildLogsStreamQuery(request *StreamLogsRequest) url.Values {
+	return newQueryBuilder().
+		SetString("id", request.ID).
+		SetString("device", request.Device).
+		SetString("status", request.Status).
+		SetString("search", request.Search).
+		SetBool("raw", request.Raw).
+		Values()
+}
+
+// StreamTo streams the logs of a profile and writes every entry to w as it arrives, either as
+// NDJSON or CSV lines. Like Stream, an interrupted stream is reopened from the last received
+// entry. It blocks until the context is canceled, returning the context error, or until an error
+// prevents to resume the stream or to write an entry.
+func (s *logsService) StreamTo(ctx context.Context, request *StreamLogsRequest, w io.Writer, format string) error {
+	var write func(*LogEntry) error
+
+	switch format {
+	case LogsFormatNDJSON:
+		encoder := json.NewEncoder(w)
+		write = func(entry *LogEntry) error {
+			return encoder.Encode(entry)
+		}
+	case LogsFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(logsCSVHeader); err != nil {
+			return fmt.Errorf("error writing the logs csv header: %w", err)
+		}
+		writer.Flush()
+		write = func(entry *LogEntry) error {
+			if err := writer.Write(logEntryCSVRecord(entry)); err != nil {
+				return err
+			}
+			writer.Flush()
+			return writer.Error()
+		}
+	default:
+		return fmt.Errorf("invalid logs format %q: must be %q or %q", format, LogsFormatNDJSON, LogsFormatCSV)
+	}
+
+	res, err := s.openStream(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	return s.resumeStream(ctx, res, request, func(entry *LogEntry) error {
+		if err := write(entry); err != nil {
+			return fmt.Errorf("error writing the log entry: %w", err)
+		}
+		return nil
+	})
+}
+
+// Stream streams the logs of a profile in real time. The entries are sent on the first channel
+// until the context is canceled. When the stream is interrupted by a network error or by the
+// server, it's reopened from the last received entry. An error preventing to resume the stream
+// is sent on the second channel. Both channels are closed when streaming stops.
+func (s *logsService) Stream(ctx context.Context, request *StreamLogsRequest) (<-chan *LogEntry, <-chan error, error) {
+	res, err := s.openStream(ctx, request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries := make(chan *LogEntry)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(entries)
+		defer close(errs)
+
+		err := s.resumeStream(ctx, res, request, func(entry *LogEntry) error {
+			select {
+			case entries <- entry:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil && ctx.Err() == nil {
+			errs <- err
+		}
+	}()
+
+	return entries, errs, nil
+}
+
+// resumeStream reads the opened logs stream res and calls fn for every entry received. When the
+// stream is interrupted, it's reopened from the last received entry. It returns the context error
+// once the context is canceled, the error returned by fn, or an error preventing to resume the
+// stream.
+func (s *logsService) resumeStream(ctx context.Context, res *http.Response, request *StreamLogsRequest, fn func(*LogEntry) error) error {
+	req := *request
+	for {
+		var fnErr error
+		err := readStream(ctx, res.Body, func(id string, entry *LogEntry) error {
+			if id != "" {
+				req.ID = id
+			}
+			fnErr = fn(entry)
+			return fnErr
+		})
+		_ = res.Body.Close()
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if fnErr != nil {
+			return fnErr
+		}
+		if err != nil && !isTransientStreamError(err) {
+			return err
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(logsStreamReconnectDelay):
+			}
+
+			res, err = s.openStream(ctx, &req)
+			if err == nil {
+				break
+			}
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			if !isTransientStreamError(err) {
+				return err
+			}
+		}
+	}
+}
+
+// logsStreamReconnectDelay is the delay before reopening an interrupted logs stream.
+var logsStreamReconnectDelay = time.Second
+
+// isTransientStreamError returns true if the logs stream can be resumed after the error, i.e.
+// when it's not an error returned by the API, apart from the service errors.
+func isTransientStreamError(err error) bool {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Type == ErrorTypeServiceError
+	}
+	var decodeErr *logsStreamDecodeError
+	return !errors.As(err, &decodeErr)
+}
+
+// logsStreamDecodeError is returned when an event of the logs stream is not a log entry.
+type logsStreamDecodeError struct {
+	err error
+}
+
+func (e *logsStreamDecodeError) Error() string {
+	return fmt.Sprintf("error decoding the log entry: %v", e.err)
+}
+
+func (e *logsStreamDecodeError) Unwrap() error {
+	return e.err
+}
+
+// openStream opens the logs stream of a profile.
+func (s *logsService) openStream(ctx context.Context, request *StreamLogsRequest) (*http.Response, error) {
+	path := fmt.Sprintf("%s/%s", profileAPIPath(request.ProfileID), logsStreamAPIPath)
+	req, err := s.client.newRequestWithQuery(http.MethodGet, path, buildLogsStreamQuery(request), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to stream logs: %w", err)
+	}
+
+	res, err := s.client.doStream(ctx, req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fmt.Errorf("error making request to stream logs: %w", err)
+	}
+
+	return res, nil
+}
+
+// readStream reads the entries of an opened logs stream and calls fn with the ID of the event
+// and the entry, until the stream is closed.
+func readStream(ctx context.Context, body io.Reader, fn func(id string, entry *LogEntry) error) error {
+	err := readEvents(body, func(id string, data []byte) error {
+		entry := &LogEntry{}
+		if err := json.Unmarshal(data, entry); err != nil {
+			return &logsStreamDecodeError{err: err}
+		}
+		return fn(id, entry)
+	})
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if err != nil {
+		return fmt.Errorf("error reading the logs stream: %w", err)
+	}
+
+	return nil
+}
+
+// readEvents reads server-sent events from r and calls fn with the last event ID and the data
+// of every event.
+func readEvents(r io.Reader, fn func(id string, data []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var id string
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// An empty line dispatches the event.
+		if line == "" {
+			if len(data) > 0 {
+				if err := fn(id, []byte(strings.Join(data, "\n"))); err != nil {
+					return err
+				}
+				data = nil
+			}
+			continue
+		}
+
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			data = append(data, strings.TrimPrefix(value, " "))
+		} else if value, ok := strings.CutPrefix(line, "id:"); ok {
+			id = strings.TrimPrefix(value, " ")
+		}
+	}
+
+	return scanner.Err()
+}
+
+// logEntryCSVRecord converts a log entry to a CSV record matching logsCSVHeader.
+func logEntryCSVRecord(entry *LogEntry) []string {
+	device := ""
+	if entry.Device != nil {
+		device = entry.Device.ID
+	}
+
+	reasons := make([]string, len(entry.Reasons))
+	for i, reason := range entry.Reasons {
+		reasons[i] = reason.ID
+	}
+
+	return []string{
+		entry.Timestamp.Format(time.RFC3339Nano),
+		entry.Domain,
+		entry.Root,
+		entry.Tracker,
+		strconv.FormatBool(entry.Encrypted),
+		entry.Protocol,
+		entry.ClientIP,
+		entry.Client,
+		device,
+		entry.Status,
+		strings.Join(reasons, ";"),
+	}
+}
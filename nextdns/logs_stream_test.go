@@ -0,0 +1,233 @@
+package nextdns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+// logsStreamEvents is a stream of two server-sent log events.
+const logsStreamEvents = `id: 1
+data: {"timestamp":"2024-01-15T10:30:00Z","domain":"example.com","root":"example.com","encrypted":true,"protocol":"DNS-over-HTTPS","clientIp":"192.168.1.100","status":"default"}
+
+: keep-alive
+
+id: 2
+data: {"timestamp":"2024-01-15T10:30:01Z","domain":"ads.example.com","root":"example.com","encrypted":true,"protocol":"DNS-over-HTTPS","clientIp":"192.168.1.100","device":{"id":"8TD1G","name":"iPhone"},"status":"blocked","reasons":[{"id":"blocklist:nextdns-recommended","name":"NextDNS Ads & Trackers Blocklist"}]}
+
+`
+
+func TestLogsStreamToNDJSON(t *testing.T) {
+	c := is.New(t)
+
+	delay := logsStreamReconnectDelay
+	logsStreamReconnectDelay = 10 * time.Millisecond
+	defer func() { logsStreamReconnectDelay = delay }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/logs/stream")
+		c.Equal(r.URL.Query().Get("status"), "blocked")
+		c.Equal(r.Header.Get("Accept"), "text/event-stream")
+
+		if r.URL.Query().Get("id") != "" {
+			// The stream is resumed after the disconnection: stop streaming.
+			cancel()
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(logsStreamEvents))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	var out bytes.Buffer
+	err = client.Logs.StreamTo(ctx, &StreamLogsRequest{
+		ProfileID: "abc123",
+		Status:    "blocked",
+	}, &out, LogsFormatNDJSON)
+	c.True(errors.Is(err, context.Canceled))
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	c.Equal(len(lines), 2)
+
+	var entry LogEntry
+	c.NoErr(json.Unmarshal([]byte(lines[1]), &entry))
+	c.Equal(entry.Domain, "ads.example.com")
+	c.Equal(entry.Device.ID, "8TD1G")
+}
+
+func TestLogsStreamToCSV(t *testing.T) {
+	c := is.New(t)
+
+	delay := logsStreamReconnectDelay
+	logsStreamReconnectDelay = 10 * time.Millisecond
+	defer func() { logsStreamReconnectDelay = delay }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") != "" {
+			cancel()
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(logsStreamEvents))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	var out bytes.Buffer
+	err = client.Logs.StreamTo(ctx, &StreamLogsRequest{ProfileID: "abc123"}, &out, LogsFormatCSV)
+	c.True(errors.Is(err, context.Canceled))
+
+	c.Equal(out.String(), "timestamp,domain,root,tracker,encrypted,protocol,clientIp,client,device,status,reasons\n"+
+		"2024-01-15T10:30:00Z,example.com,example.com,,true,DNS-over-HTTPS,192.168.1.100,,,default,\n"+
+		"2024-01-15T10:30:01Z,ads.example.com,example.com,,true,DNS-over-HTTPS,192.168.1.100,,8TD1G,blocked,blocklist:nextdns-recommended\n")
+}
+
+func TestLogsStreamToResume(t *testing.T) {
+	c := is.New(t)
+
+	delay := logsStreamReconnectDelay
+	logsStreamReconnectDelay = 10 * time.Millisecond
+	defer func() { logsStreamReconnectDelay = delay }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first, second, _ := strings.Cut(logsStreamEvents, "\n\n")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		switch r.URL.Query().Get("id") {
+		case "":
+			// Write the first event, then drop the connection.
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(first + "\n\n"))
+			c.NoErr(err)
+		case "1":
+			// The stream is resumed from the first event: write the second one.
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(second))
+			c.NoErr(err)
+		default:
+			cancel()
+		}
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	var out bytes.Buffer
+	err = client.Logs.StreamTo(ctx, &StreamLogsRequest{ProfileID: "abc123"}, &out, LogsFormatNDJSON)
+	c.True(errors.Is(err, context.Canceled))
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	c.Equal(len(lines), 2)
+	c.True(strings.Contains(lines[0], `"domain":"example.com"`))
+	c.True(strings.Contains(lines[1], `"domain":"ads.example.com"`))
+}
+
+func TestLogsStreamToInvalidFormat(t *testing.T) {
+	c := is.New(t)
+
+	client, err := New()
+	c.NoErr(err)
+
+	var out bytes.Buffer
+	err = client.Logs.StreamTo(context.Background(), &StreamLogsRequest{ProfileID: "abc123"}, &out, "xml")
+	c.True(err != nil)
+}
+
+func TestLogsStream(t *testing.T) {
+	c := is.New(t)
+
+	delay := logsStreamReconnectDelay
+	logsStreamReconnectDelay = 10 * time.Millisecond
+	defer func() { logsStreamReconnectDelay = delay }()
+
+	resumed := make(chan string, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.URL.Path, "/profiles/abc123/logs/stream")
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+
+		if id := r.URL.Query().Get("id"); id != "" {
+			// The stream is resumed after the disconnection: keep it open.
+			resumed <- id
+			<-r.Context().Done()
+			return
+		}
+
+		// Write the events, then close the stream.
+		_, err := w.Write([]byte(logsStreamEvents))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	entries, errs, err := client.Logs.Stream(ctx, &StreamLogsRequest{ProfileID: "abc123"})
+	c.NoErr(err)
+
+	first := <-entries
+	c.Equal(first.Domain, "example.com")
+	second := <-entries
+	c.Equal(second.Domain, "ads.example.com")
+	c.Equal(second.Reasons[0].ID, "blocklist:nextdns-recommended")
+
+	c.Equal(<-resumed, "2")
+
+	cancel()
+	_, ok := <-entries
+	c.True(!ok)
+	_, ok = <-errs
+	c.True(!ok)
+}
+
+func TestLogsStreamError(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(`{"errors": [{"code": "notFound"}]}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	entries, errs, err := client.Logs.Stream(context.Background(), &StreamLogsRequest{ProfileID: "abc123"})
+	c.True(err != nil)
+	c.Equal(entries, nil)
+	c.Equal(errs, nil)
+}
@@ -3,9 +3,12 @@ package nextdns
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/matryer/is"
 )
@@ -192,3 +195,247 @@ func TestLogsClear(t *testing.T) {
 
 	c.NoErr(err)
 }
+
+func TestParseLogsQuery(t *testing.T) {
+	c := is.New(t)
+
+	query, err := url.ParseQuery("from=-1d&to=now&sort=asc&limit=50&cursor=abc&device=8TD1G&status=blocked&search=google&raw=1")
+	c.NoErr(err)
+
+	opts, err := ParseLogsQuery(query)
+	c.NoErr(err)
+	c.Equal(opts, &LogsQueryOptions{
+		From:   "-1d",
+		To:     "now",
+		Sort:   "asc",
+		Limit:  50,
+		Cursor: "abc",
+		Device: "8TD1G",
+		Status: "blocked",
+		Search: "google",
+		Raw:    true,
+	})
+
+	// The options round-trip to the same query.
+	c.Equal(buildLogsQuery(opts).Encode(), "cursor=abc&device=8TD1G&from=-1d&limit=50&raw=true&search=google&sort=asc&status=blocked&to=now")
+}
+
+func TestParseLogsQueryMalformed(t *testing.T) {
+	c := is.New(t)
+
+	_, err := ParseLogsQuery(url.Values{"limit": {"many"}})
+	c.True(err != nil)
+
+	_, err = ParseLogsQuery(url.Values{"limit": {"5000"}})
+	c.True(err != nil)
+
+	_, err = ParseLogsQuery(url.Values{"sort": {"up"}})
+	c.True(err != nil)
+
+	_, err = ParseLogsQuery(url.Values{"status": {"unknown"}})
+	c.True(err != nil)
+}
+
+func TestLogsGetSince(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/logs")
+		c.Equal(r.URL.Query().Get("from"), "2024-01-15T10:30:00Z")
+		c.Equal(r.URL.Query().Get("sort"), "asc")
+		c.Equal(r.URL.Query().Get("status"), "blocked")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{"data": [], "meta": {"pagination": {"cursor": ""}, "stream": {"id": ""}}}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	since := time.Date(2024, 1, 15, 11, 30, 0, 0, time.FixedZone("CET", 3600))
+	opts := &LogsQueryOptions{Status: "blocked", Sort: "desc"}
+	_, err = client.Logs.GetSince(ctx, "abc123", since, opts)
+
+	c.NoErr(err)
+	c.Equal(opts.Sort, SortOrderDesc)
+}
+
+func TestLogEntryExplain(t *testing.T) {
+	c := is.New(t)
+
+	blocked := &LogEntry{
+		Status: "blocked",
+		Reasons: []LogReason{
+			{ID: "blocklist:easylist", Name: "EasyList"},
+			{ID: "blocklist:oisd"},
+		},
+	}
+	c.Equal(blocked.Explain(), "blocked by EasyList, blocklist:oisd")
+
+	allowed := &LogEntry{
+		Status:  "allowed",
+		Reasons: []LogReason{{ID: "allowlist", Name: "Allowlist"}},
+	}
+	c.Equal(allowed.Explain(), "allowed by Allowlist")
+
+	c.Equal((&LogEntry{Status: "blocked"}).Explain(), "blocked")
+	c.Equal((&LogEntry{Status: "default"}).Explain(), "default resolved")
+	c.Equal((&LogEntry{}).Explain(), "default resolved")
+	c.Equal((&LogEntry{Status: "error"}).Explain(), "resolution error")
+}
+
+func TestLogsGetSortOrder(t *testing.T) {
+	c := is.New(t)
+
+	var sorts []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sorts = append(sorts, r.URL.Query().Get("sort"))
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": [], "meta": {"pagination": {"cursor": ""}, "stream": {"id": ""}}}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	for _, sort := range []SortOrder{"", SortOrderAsc, SortOrderDesc} {
+		_, err = client.Logs.Get(ctx, &GetLogsRequest{
+			ProfileID: "abc123",
+			Options:   &LogsQueryOptions{Sort: sort},
+		})
+		c.NoErr(err)
+	}
+	c.Equal(sorts, []string{"desc", "asc", "desc"})
+
+	_, err = client.Logs.Get(ctx, &GetLogsRequest{
+		ProfileID: "abc123",
+		Options:   &LogsQueryOptions{Sort: "newest"},
+	})
+	c.True(errors.Is(err, ErrInvalidSortOrder))
+	c.Equal(len(sorts), 3) // the invalid request is never sent
+}
+
+func TestFilterLogs(t *testing.T) {
+	c := is.New(t)
+
+	entries := []*LogEntry{
+		{Domain: "a.example.com", Encrypted: true, Protocol: "DNS-over-HTTPS"},
+		{Domain: "b.example.com", Encrypted: false, Protocol: "UDP"},
+		{Domain: "c.example.com", Encrypted: true, Protocol: "DNS-over-TLS"},
+		{Domain: "d.example.com", Encrypted: false, Protocol: "TCP"},
+	}
+
+	unencrypted := FilterLogs(entries, func(e *LogEntry) bool { return !e.Encrypted })
+	c.Equal(len(unencrypted), 2)
+	c.Equal(unencrypted[0].Domain, "b.example.com")
+	c.Equal(unencrypted[1].Domain, "d.example.com")
+
+	dot := FilterLogs(entries, func(e *LogEntry) bool { return e.Protocol == "DNS-over-TLS" })
+	c.Equal(len(dot), 1)
+	c.Equal(dot[0].Domain, "c.example.com")
+
+	c.Equal(len(FilterLogs(nil, func(*LogEntry) bool { return true })), 0)
+}
+
+func TestLogsGetAll(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/logs")
+		c.Equal(r.URL.Query().Get("status"), "blocked")
+
+		var resp string
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			resp = `{"data": [{"domain": "a.com"}, {"domain": "b.com"}], "meta": {"pagination": {"cursor": "page2"}}}`
+		case "page2":
+			resp = `{"data": [{"domain": "c.com"}], "meta": {"pagination": {"cursor": ""}}}`
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	request := &GetLogsRequest{ProfileID: "abc123", Options: &LogsQueryOptions{Status: "blocked"}}
+
+	entries, err := client.Logs.GetAll(ctx, request, 0)
+	c.NoErr(err)
+	c.Equal(len(entries), 3)
+	c.Equal(entries[0].Domain, "a.com")
+	c.Equal(entries[1].Domain, "b.com")
+	c.Equal(entries[2].Domain, "c.com")
+	c.Equal(request.Options.Cursor, "")
+
+	entries, err = client.Logs.GetAll(ctx, request, 2)
+	c.NoErr(err)
+	c.Equal(len(entries), 2)
+	c.Equal(entries[1].Domain, "b.com")
+}
+
+func TestLogsGetAllStableCursor(t *testing.T) {
+	c := is.New(t)
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": [], "meta": {"pagination": {"cursor": "same"}}}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	entries, err := client.Logs.GetAll(context.Background(), &GetLogsRequest{ProfileID: "abc123"}, 0)
+	c.NoErr(err)
+	c.Equal(len(entries), 0)
+	c.Equal(calls, 1)
+}
+
+func TestLogsGetAllCanceled(t *testing.T) {
+	c := is.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The context is canceled while the second page is fetched.
+		if r.URL.Query().Get("cursor") == "page2" {
+			cancel()
+			<-r.Context().Done()
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": [{"domain": "a.com"}, {"domain": "b.com"}], "meta": {"pagination": {"cursor": "page2"}}}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	entries, err := client.Logs.GetAll(ctx, &GetLogsRequest{ProfileID: "abc123"}, 0)
+	c.True(errors.Is(err, context.Canceled))
+	c.Equal(len(entries), 2)
+	c.Equal(entries[1].Domain, "b.com")
+}
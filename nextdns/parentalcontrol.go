@@ -57,6 +57,8 @@ type GetParentalControlRequest struct {
 type ParentalControlService interface {
 	Get(context.Context, *GetParentalControlRequest) (*ParentalControl, error)
 	Update(context.Context, *UpdateParentalControlRequest) error
+	SetBlockBypass(ctx context.Context, profileID string, on bool) error
+	SetSafeMode(ctx context.Context, profileID string, on bool) error
 }
 
 // parentalControlResponse represents the NextDNS parental control service.
@@ -66,7 +68,7 @@ type parentalControlResponse struct {
 
 // parentalControlService represents the NextDNS parental control service.
 type parentalControlService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ ParentalControlService = &parentalControlService{}
@@ -75,7 +77,7 @@ var _ ParentalControlService = &parentalControlService{}
 // nolint: revive
 func NewParentalControlService(client *Client) *parentalControlService {
 	return &parentalControlService{
-		client: client,
+		client: newServiceClient(client, ServiceParentalControl),
 	}
 }
 
@@ -104,11 +106,59 @@ func (s *parentalControlService) Update(ctx context.Context, request *UpdatePare
 		return fmt.Errorf("error creating request to update the parentalControl: %w", err)
 	}
 
-	response := parentalControlResponse{}
-	err = s.client.do(ctx, req, &response)
+	err = s.client.do(ctx, req, nil)
 	if err != nil {
 		return fmt.Errorf("error making a request to update the parentalControl: %w", err)
 	}
 
 	return nil
 }
+
+// SetBlockBypass turns the block bypass prevention of a profile on or off. Only the blockBypass
+// field is sent, so the other parental control settings are left unchanged.
+func (s *parentalControlService) SetBlockBypass(ctx context.Context, profileID string, on bool) error {
+	path := fmt.Sprintf("%s/%s", profileAPIPath(profileID), parentalControlAPIPath)
+	body := struct {
+		BlockBypass *bool `json:"blockBypass"`
+	}{
+		BlockBypass: &on,
+	}
+	req, err := s.client.newRequest(http.MethodPatch, path, body)
+	if err != nil {
+		return fmt.Errorf("error creating request to set the parentalControl block bypass: %w", err)
+	}
+
+	err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return fmt.Errorf("error making a request to set the parentalControl block bypass: %w", err)
+	}
+
+	return nil
+}
+
+// SetSafeMode turns the "kid mode" of a profile on or off: the safe search, the YouTube restricted
+// mode and the block bypass prevention together. Only these three fields are sent, so the other
+// parental control settings are left unchanged.
+func (s *parentalControlService) SetSafeMode(ctx context.Context, profileID string, on bool) error {
+	path := fmt.Sprintf("%s/%s", profileAPIPath(profileID), parentalControlAPIPath)
+	body := struct {
+		SafeSearch            *bool `json:"safeSearch"`
+		YoutubeRestrictedMode *bool `json:"youtubeRestrictedMode"`
+		BlockBypass           *bool `json:"blockBypass"`
+	}{
+		SafeSearch:            &on,
+		YoutubeRestrictedMode: &on,
+		BlockBypass:           &on,
+	}
+	req, err := s.client.newRequest(http.MethodPatch, path, body)
+	if err != nil {
+		return fmt.Errorf("error creating request to set the parentalControl safe mode: %w", err)
+	}
+
+	err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return fmt.Errorf("error making a request to set the parentalControl safe mode: %w", err)
+	}
+
+	return nil
+}
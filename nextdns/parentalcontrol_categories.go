@@ -44,11 +44,12 @@ type ParentalControlCategoriesService interface {
 // parentalControlCategoriesResponse represents the parental control categories response.
 type parentalControlCategoriesResponse struct {
 	ParentalControlCategories []*ParentalControlCategories `json:"data"`
+	listMeta
 }
 
 // parentalControlCategoriesService represents the NextDNS parental control categories service.
 type parentalControlCategoriesService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ ParentalControlCategoriesService = &parentalControlCategoriesService{}
@@ -57,7 +58,7 @@ var _ ParentalControlCategoriesService = &parentalControlCategoriesService{}
 // nolint: revive
 func NewParentalControlCategoriesService(client *Client) *parentalControlCategoriesService {
 	return &parentalControlCategoriesService{
-		client: client,
+		client: newServiceClient(client, ServiceParentalControlCategories),
 	}
 }
 
@@ -69,8 +70,7 @@ func (s *parentalControlCategoriesService) Create(ctx context.Context, request *
 		return fmt.Errorf("error creating request to create a parental control categories: %w", err)
 	}
 
-	response := parentalControlCategoriesResponse{}
-	err = s.client.do(ctx, req, &response)
+	err = s.client.do(ctx, req, nil)
 	if err != nil {
 		return fmt.Errorf("error making a request to create a parental control categories: %w", err)
 	}
@@ -103,8 +103,7 @@ func (s *parentalControlCategoriesService) Update(ctx context.Context, request *
 		return fmt.Errorf("error creating request to update the parental control categories: %w", err)
 	}
 
-	response := parentalControlCategoriesResponse{}
-	err = s.client.do(ctx, req, &response)
+	err = s.client.do(ctx, req, nil)
 	if err != nil {
 		return fmt.Errorf("error making a request to update the parental control categories: %w", err)
 	}
@@ -23,10 +23,15 @@ type CreateParentalControlServicesRequest struct {
 }
 
 // UpdateParentalControlServicesRequest encapsulates the request for updating a parental control services list.
+// To patch a single field, leave ParentalControlServices nil and set only Active or Recreation:
+// only the non-nil fields are sent, so the other field is left untouched. When
+// ParentalControlServices is set, it's sent as is and both fields are replaced.
 type UpdateParentalControlServicesRequest struct {
 	ProfileID               string
 	ID                      string
 	ParentalControlServices *ParentalControlServices
+	Active                  *bool
+	Recreation              *bool
 }
 
 // ListParentalControlServicesRequest encapsulates the request for getting a parental control services list.
@@ -39,16 +44,18 @@ type ParentalControlServicesService interface {
 	Create(context.Context, *CreateParentalControlServicesRequest) error
 	List(context.Context, *ListParentalControlServicesRequest) ([]*ParentalControlServices, error)
 	Update(context.Context, *UpdateParentalControlServicesRequest) error
+	SetRecreation(ctx context.Context, profileID string, ids []string, recreation bool) error
 }
 
 // parentalControlServicesResponse represents the NextDNS parental control services service.
 type parentalControlServicesResponse struct {
 	ParentalControlServices []*ParentalControlServices `json:"data"`
+	listMeta
 }
 
 // parentalControlServicesService represents the NextDNS parental control services service.
 type parentalControlServicesService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ ParentalControlServicesService = &parentalControlServicesService{}
@@ -57,7 +64,7 @@ var _ ParentalControlServicesService = &parentalControlServicesService{}
 // nolint: revive
 func NewParentalControlServicesService(client *Client) *parentalControlServicesService {
 	return &parentalControlServicesService{
-		client: client,
+		client: newServiceClient(client, ServiceParentalControlServices),
 	}
 }
 
@@ -69,8 +76,7 @@ func (s *parentalControlServicesService) Create(ctx context.Context, request *Cr
 		return fmt.Errorf("error creating request to create a parental control services: %w", err)
 	}
 
-	response := parentalControlServicesResponse{}
-	err = s.client.do(ctx, req, &response)
+	err = s.client.do(ctx, req, nil)
 	if err != nil {
 		return fmt.Errorf("error making a request to create a parental control services: %w", err)
 	}
@@ -98,13 +104,22 @@ func (s *parentalControlServicesService) List(ctx context.Context, request *List
 // Update updates a parental control services list.
 func (s *parentalControlServicesService) Update(ctx context.Context, request *UpdateParentalControlServicesRequest) error {
 	path := fmt.Sprintf("%s/%s", profileAPIPath(request.ProfileID), parentalControlServicesIDAPIPath(request.ID))
-	req, err := s.client.newRequest(http.MethodPatch, path, request.ParentalControlServices)
+	var body interface{} = request.ParentalControlServices
+	if request.ParentalControlServices == nil {
+		body = struct {
+			Active     *bool `json:"active,omitempty"`
+			Recreation *bool `json:"recreation,omitempty"`
+		}{
+			Active:     request.Active,
+			Recreation: request.Recreation,
+		}
+	}
+	req, err := s.client.newRequest(http.MethodPatch, path, body)
 	if err != nil {
 		return fmt.Errorf("error creating request to update the parental control services: %w", err)
 	}
 
-	response := parentalControlServicesResponse{}
-	err = s.client.do(ctx, req, &response)
+	err = s.client.do(ctx, req, nil)
 	if err != nil {
 		return fmt.Errorf("error making a request to update the parental control services: %w", err)
 	}
@@ -112,6 +127,31 @@ func (s *parentalControlServicesService) Update(ctx context.Context, request *Up
 	return nil
 }
 
+// SetRecreation sets the recreation mode of multiple parental control services, issuing a request per service.
+// Only the recreation flag is sent, so the active state of the services is left untouched.
+func (s *parentalControlServicesService) SetRecreation(ctx context.Context, profileID string, ids []string, recreation bool) error {
+	body := struct {
+		Recreation bool `json:"recreation"`
+	}{
+		Recreation: recreation,
+	}
+
+	for _, id := range ids {
+		path := fmt.Sprintf("%s/%s", profileAPIPath(profileID), parentalControlServicesIDAPIPath(id))
+		req, err := s.client.newRequest(http.MethodPatch, path, body)
+		if err != nil {
+			return fmt.Errorf("error creating request to set the recreation of parental control service %s: %w", id, err)
+		}
+
+		err = s.client.do(ctx, req, nil)
+		if err != nil {
+			return fmt.Errorf("error making a request to set the recreation of parental control service %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
 // parentalControlServicesIDAPIPath returns the HTTP path for the parental control services API.
 func parentalControlServicesIDAPIPath(id string) string {
 	return fmt.Sprintf("%s/%s", parentalControlServicesAPIPath, id)
@@ -0,0 +1,68 @@
+package nextdns
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParentalControlServicesSetRecreation(t *testing.T) {
+	c := is.New(t)
+
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PATCH")
+		paths = append(paths, r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		c.NoErr(err)
+		c.Equal(string(body), "{\"recreation\":true}\n")
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	err = client.ParentalControlServices.SetRecreation(ctx, "abc123", []string{"tiktok", "fortnite"}, true)
+
+	c.NoErr(err)
+	c.Equal(paths, []string{
+		"/profiles/abc123/parentalControl/services/tiktok",
+		"/profiles/abc123/parentalControl/services/fortnite",
+	})
+}
+
+func TestParentalControlServicesUpdatePartial(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PATCH")
+		c.Equal(r.URL.Path, "/profiles/abc123/parentalControl/services/tiktok")
+
+		body, err := io.ReadAll(r.Body)
+		c.NoErr(err)
+		c.Equal(string(body), `{"active":false}`+"\n")
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	active := false
+	err = client.ParentalControlServices.Update(context.Background(), &UpdateParentalControlServicesRequest{
+		ProfileID: "abc123",
+		ID:        "tiktok",
+		Active:    &active,
+	})
+
+	c.NoErr(err)
+}
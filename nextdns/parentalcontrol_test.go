@@ -0,0 +1,59 @@
+package nextdns
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParentalControlSetBlockBypass(t *testing.T) {
+	c := is.New(t)
+
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PATCH")
+		c.Equal(r.URL.Path, "/profiles/abc123/parentalControl")
+
+		body, err := io.ReadAll(r.Body)
+		c.NoErr(err)
+		bodies = append(bodies, string(body))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	c.NoErr(client.ParentalControl.SetBlockBypass(ctx, "abc123", true))
+	c.NoErr(client.ParentalControl.SetBlockBypass(ctx, "abc123", false))
+
+	c.Equal(bodies, []string{"{\"blockBypass\":true}\n", "{\"blockBypass\":false}\n"})
+}
+
+func TestParentalControlSetSafeMode(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PATCH")
+		c.Equal(r.URL.Path, "/profiles/abc123/parentalControl")
+
+		body, err := io.ReadAll(r.Body)
+		c.NoErr(err)
+		c.Equal(string(body), "{\"safeSearch\":true,\"youtubeRestrictedMode\":true,\"blockBypass\":true}\n")
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	err = client.ParentalControl.SetSafeMode(context.Background(), "abc123", true)
+	c.NoErr(err)
+}
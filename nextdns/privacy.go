@@ -32,6 +32,7 @@ type GetPrivacyRequest struct {
 type PrivacyService interface {
 	Get(context.Context, *GetPrivacyRequest) (*Privacy, error)
 	Update(context.Context, *UpdatePrivacyRequest) error
+	SetDisguisedTrackers(ctx context.Context, profileID string, on bool) error
 }
 
 // privacyResponse represents the NextDNS privacy settings service.
@@ -41,7 +42,7 @@ type privacyResponse struct {
 
 // privacyService represents the NextDNS privacy settings service.
 type privacyService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ PrivacyService = &privacyService{}
@@ -50,7 +51,7 @@ var _ PrivacyService = &privacyService{}
 // nolint: revive
 func NewPrivacyService(client *Client) *privacyService {
 	return &privacyService{
-		client: client,
+		client: newServiceClient(client, ServicePrivacy),
 	}
 }
 
@@ -79,11 +80,32 @@ func (s *privacyService) Update(ctx context.Context, request *UpdatePrivacyReque
 		return fmt.Errorf("error creating request to update the privacy: %w", err)
 	}
 
-	response := privacyResponse{}
-	err = s.client.do(ctx, req, &response)
+	err = s.client.do(ctx, req, nil)
 	if err != nil {
 		return fmt.Errorf("error making a request to update the privacy: %w", err)
 	}
 
 	return nil
 }
+
+// SetDisguisedTrackers enables or disables the disguised trackers blocking of a profile.
+// Only the disguisedTrackers field is sent, so the other privacy settings are left untouched.
+func (s *privacyService) SetDisguisedTrackers(ctx context.Context, profileID string, on bool) error {
+	path := fmt.Sprintf("%s/%s", profileAPIPath(profileID), privacyAPIPath)
+	body := struct {
+		DisguisedTrackers *bool `json:"disguisedTrackers,omitempty"`
+	}{
+		DisguisedTrackers: &on,
+	}
+	req, err := s.client.newRequest(http.MethodPatch, path, body)
+	if err != nil {
+		return fmt.Errorf("error creating request to set the disguised trackers: %w", err)
+	}
+
+	err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return fmt.Errorf("error making a request to set the disguised trackers: %w", err)
+	}
+
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -58,19 +60,26 @@ type DeletePrivacyBlocklistsRequest struct {
 type PrivacyBlocklistsService interface {
 	Create(context.Context, *CreatePrivacyBlocklistsRequest) error
 	List(context.Context, *ListPrivacyBlocklistsRequest) ([]*PrivacyBlocklists, error)
+	ListWithMeta(context.Context, *ListPrivacyBlocklistsRequest) (*ListResponse[*PrivacyBlocklists], error)
 	Add(context.Context, *AddPrivacyBlocklistsRequest) error
 	Update(context.Context, *UpdatePrivacyBlocklistsRequest) error
 	Delete(context.Context, *DeletePrivacyBlocklistsRequest) error
+	Search(context.Context, string) ([]*PrivacyBlocklists, error)
 }
 
 // privacyBlocklistsResponse represents the NextDNS privacy blocklist service.
 type privacyBlocklistsResponse struct {
 	PrivacyBlocklists []*PrivacyBlocklists `json:"data"`
+	listMeta
 }
 
 // privacyBlocklistsService represents the NextDNS privacy blocklist service.
 type privacyBlocklistsService struct {
-	client *Client
+	client *serviceClient
+
+	// catalog caches the available privacy blocklists.
+	mu      sync.Mutex
+	catalog []*PrivacyBlocklists
 }
 
 var _ PrivacyBlocklistsService = &privacyBlocklistsService{}
@@ -79,7 +88,7 @@ var _ PrivacyBlocklistsService = &privacyBlocklistsService{}
 // nolint: revive
 func NewPrivacyBlocklistsService(client *Client) *privacyBlocklistsService {
 	return &privacyBlocklistsService{
-		client: client,
+		client: newServiceClient(client, ServicePrivacyBlocklists),
 	}
 }
 
@@ -91,10 +100,13 @@ func (s *privacyBlocklistsService) Create(ctx context.Context, request *CreatePr
 		return fmt.Errorf("error creating request to create a privacy blocklist: %w", err)
 	}
 
-	response := privacyBlocklistsResponse{}
-	err = s.client.do(ctx, req, &response)
+	err = s.client.do(ctx, req, nil)
 	if err != nil {
-		return fmt.Errorf("error making a request to create a privacy blocklist: %w", err)
+		ids := make([]string, len(request.PrivacyBlocklists))
+		for i, entry := range request.PrivacyBlocklists {
+			ids[i] = entry.ID
+		}
+		return fmt.Errorf("error making a request to create a privacy blocklist: %w", newBulkError(err, ids))
 	}
 
 	return nil
@@ -102,6 +114,16 @@ func (s *privacyBlocklistsService) Create(ctx context.Context, request *CreatePr
 
 // List returns the privacy blocklist for a profile.
 func (s *privacyBlocklistsService) List(ctx context.Context, request *ListPrivacyBlocklistsRequest) ([]*PrivacyBlocklists, error) {
+	response, err := s.ListWithMeta(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// ListWithMeta returns the privacy blocklist along with its count.
+func (s *privacyBlocklistsService) ListWithMeta(ctx context.Context, request *ListPrivacyBlocklistsRequest) (*ListResponse[*PrivacyBlocklists], error) {
 	path := fmt.Sprintf("%s/%s", profileAPIPath(request.ProfileID), privacyBlocklistsAPIPath)
 	req, err := s.client.newRequest(http.MethodGet, path, nil)
 	if err != nil {
@@ -114,7 +136,7 @@ func (s *privacyBlocklistsService) List(ctx context.Context, request *ListPrivac
 		return nil, fmt.Errorf("error making a request to list the privacy blocklist: %w", err)
 	}
 
-	return response.PrivacyBlocklists, nil
+	return newListResponse(response.PrivacyBlocklists, response.listMeta), nil
 }
 
 // Add adds a single blocklist to the privacy settings.
@@ -174,3 +196,51 @@ func (s *privacyBlocklistsService) Delete(ctx context.Context, request *DeletePr
 
 	return nil
 }
+
+// Search returns the available privacy blocklists whose ID or name contains the query, ignoring case.
+// The catalog of blocklists is fetched once and cached for the lifetime of the service.
+func (s *privacyBlocklistsService) Search(ctx context.Context, query string) ([]*PrivacyBlocklists, error) {
+	catalog, err := s.getCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	results := make([]*PrivacyBlocklists, 0)
+	for _, blocklist := range catalog {
+		if strings.Contains(strings.ToLower(blocklist.ID), query) || strings.Contains(strings.ToLower(blocklist.Name), query) {
+			results = append(results, blocklist)
+		}
+	}
+
+	return results, nil
+}
+
+// getCatalog returns the cached catalog of privacy blocklists, fetching it on the first call.
+func (s *privacyBlocklistsService) getCatalog(ctx context.Context) ([]*PrivacyBlocklists, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.catalog != nil {
+		return s.catalog, nil
+	}
+
+	// The catalog is served under the same path as the blocklists of a profile, at the root of
+	// the API instead of the profile path.
+	req, err := s.client.newRequest(http.MethodGet, privacyBlocklistsAPIPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to get the privacy blocklist catalog: %w", err)
+	}
+
+	response := privacyBlocklistsResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error making a request to get the privacy blocklist catalog: %w", err)
+	}
+
+	s.catalog = response.PrivacyBlocklists
+	if s.catalog == nil {
+		s.catalog = []*PrivacyBlocklists{}
+	}
+	return s.catalog, nil
+}
@@ -2,6 +2,7 @@ package nextdns
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -88,3 +89,125 @@ func TestPrivacyBlocklistsDelete(t *testing.T) {
 
 	c.NoErr(err)
 }
+
+func TestPrivacyBlocklistsDeleteNoContent(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "DELETE")
+		c.Equal(r.URL.Path, "/profiles/abc123/privacy/blocklists/oisd")
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	err = client.PrivacyBlocklists.Delete(ctx, &DeletePrivacyBlocklistsRequest{
+		ProfileID:   "abc123",
+		BlocklistID: "oisd",
+	})
+
+	c.NoErr(err)
+}
+
+func TestPrivacyBlocklistsCreateEntryErrors(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PUT")
+		c.Equal(r.URL.Path, "/profiles/abc123/privacy/blocklists")
+
+		w.WriteHeader(http.StatusBadRequest)
+		resp := `{"errors": [{"code": "invalid", "detail": "Unknown blocklist", "source": {"pointer": "/1/id"}}]}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	err = client.PrivacyBlocklists.Create(ctx, &CreatePrivacyBlocklistsRequest{
+		ProfileID: "abc123",
+		PrivacyBlocklists: []*PrivacyBlocklists{
+			{ID: "nextdns-recommended"},
+			{ID: "not-a-blocklist"},
+		},
+	})
+	c.True(err != nil)
+
+	var bulkErr *BulkError
+	c.True(errors.As(err, &bulkErr))
+	c.Equal(len(bulkErr.Entries), 1)
+	c.Equal(bulkErr.Entries["not-a-blocklist"].Code, "invalid")
+	c.Equal(bulkErr.Entries["not-a-blocklist"].Detail, "Unknown blocklist")
+	c.True(HasErrorCode(err, "invalid"))
+}
+
+func TestPrivacyBlocklistsSearch(t *testing.T) {
+	c := is.New(t)
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/privacy/blocklists")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{"data": [
+			{"id": "nextdns-recommended", "name": "NextDNS Ads & Trackers Blocklist"},
+			{"id": "oisd", "name": "OISD"},
+			{"id": "easylist", "name": "EasyList"}
+		]}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	results, err := client.PrivacyBlocklists.Search(ctx, "list")
+	c.NoErr(err)
+	c.Equal(len(results), 2)
+	c.Equal(results[0].ID, "nextdns-recommended")
+	c.Equal(results[1].ID, "easylist")
+
+	results, err = client.PrivacyBlocklists.Search(ctx, "OISD")
+	c.NoErr(err)
+	c.Equal(len(results), 1)
+	c.Equal(results[0].ID, "oisd")
+
+	// The catalog is fetched only once.
+	c.Equal(calls, 1)
+}
+
+func TestPrivacyBlocklistsListWithMeta(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/privacy/blocklists")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": [{"id": "nextdns-recommended"}, {"id": "oisd"}, {"id": "easylist"}]}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	response, err := client.PrivacyBlocklists.ListWithMeta(ctx, &ListPrivacyBlocklistsRequest{ProfileID: "abc123"})
+
+	c.NoErr(err)
+	c.Equal(response.Count, 3)
+	c.Equal(len(response.Data), 3)
+	c.Equal(response.Cursor, "")
+}
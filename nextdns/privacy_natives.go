@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 )
 
 // privacyNativesAPIPath is the HTTP path for the privacy native tracking protection API.
@@ -53,19 +54,22 @@ type DeletePrivacyNativesRequest struct {
 type PrivacyNativesService interface {
 	Create(context.Context, *CreatePrivacyNativesRequest) error
 	List(context.Context, *ListPrivacyNativesRequest) ([]*PrivacyNatives, error)
+	ListWithMeta(context.Context, *ListPrivacyNativesRequest) (*ListResponse[*PrivacyNatives], error)
 	Add(context.Context, *AddPrivacyNativesRequest) error
 	Update(context.Context, *UpdatePrivacyNativesRequest) error
 	Delete(context.Context, *DeletePrivacyNativesRequest) error
+	Status(context.Context, string) ([]string, []string, error)
 }
 
 // privacyNativesResponse represents the NextDNS privacy native tracking protection service.
 type privacyNativesResponse struct {
 	PrivacyNatives []*PrivacyNatives `json:"data"`
+	listMeta
 }
 
 // privacyNativesService represents the NextDNS privacy native tracking protection service.
 type privacyNativesService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ PrivacyNativesService = &privacyNativesService{}
@@ -74,7 +78,7 @@ var _ PrivacyNativesService = &privacyNativesService{}
 // nolint: revive
 func NewPrivacyNativesService(client *Client) *privacyNativesService {
 	return &privacyNativesService{
-		client: client,
+		client: newServiceClient(client, ServicePrivacyNatives),
 	}
 }
 
@@ -86,10 +90,13 @@ func (s *privacyNativesService) Create(ctx context.Context, request *CreatePriva
 		return fmt.Errorf("error creating request to create a privacy native list: %w", err)
 	}
 
-	response := privacyNativesResponse{}
-	err = s.client.do(ctx, req, &response)
+	err = s.client.do(ctx, req, nil)
 	if err != nil {
-		return fmt.Errorf("error making a request to create a privacy native list: %w", err)
+		ids := make([]string, len(request.PrivacyNatives))
+		for i, entry := range request.PrivacyNatives {
+			ids[i] = entry.ID
+		}
+		return fmt.Errorf("error making a request to create a privacy native list: %w", newBulkError(err, ids))
 	}
 
 	return nil
@@ -97,6 +104,16 @@ func (s *privacyNativesService) Create(ctx context.Context, request *CreatePriva
 
 // List returns the privacy native tracking protection list.
 func (s *privacyNativesService) List(ctx context.Context, request *ListPrivacyNativesRequest) ([]*PrivacyNatives, error) {
+	response, err := s.ListWithMeta(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// ListWithMeta returns the privacy native list along with its count.
+func (s *privacyNativesService) ListWithMeta(ctx context.Context, request *ListPrivacyNativesRequest) (*ListResponse[*PrivacyNatives], error) {
 	path := fmt.Sprintf("%s/%s", profileAPIPath(request.ProfileID), privacyNativesAPIPath)
 	req, err := s.client.newRequest(http.MethodGet, path, nil)
 	if err != nil {
@@ -109,7 +126,7 @@ func (s *privacyNativesService) List(ctx context.Context, request *ListPrivacyNa
 		return nil, fmt.Errorf("error making a request to list the privacy native list: %w", err)
 	}
 
-	return response.PrivacyNatives, nil
+	return newListResponse(response.PrivacyNatives, response.listMeta), nil
 }
 
 // Add adds a single native tracking protection.
@@ -169,3 +186,43 @@ func (s *privacyNativesService) Delete(ctx context.Context, request *DeletePriva
 
 	return nil
 }
+
+// Status returns the IDs of the native tracking protections enabled on the profile, and the IDs of
+// the ones available but not enabled yet, in the order of the catalog.
+func (s *privacyNativesService) Status(ctx context.Context, profileID string) ([]string, []string, error) {
+	natives, err := s.List(ctx, &ListPrivacyNativesRequest{ProfileID: profileID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	// The catalog is served under the same path as the natives of a profile, at the root of the
+	// API instead of the profile path.
+	req, err := s.client.newRequest(http.MethodGet, privacyNativesAPIPath, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request to get the privacy native catalog: %w", err)
+	}
+
+	response := privacyNativesResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error making a request to get the privacy native catalog: %w", err)
+	}
+
+	enabled := make([]string, 0, len(natives))
+	for _, native := range natives {
+		enabled = append(enabled, native.ID)
+	}
+
+	available := []string{}
+	for _, native := range response.PrivacyNatives {
+		if !slices.Contains(enabled, native.ID) {
+			available = append(available, native.ID)
+		}
+	}
+
+	return enabled, available, nil
+}
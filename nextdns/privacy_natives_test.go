@@ -88,3 +88,85 @@ func TestPrivacyNativesDelete(t *testing.T) {
 
 	c.NoErr(err)
 }
+
+func TestPrivacyNativesStatus(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+
+		var resp string
+		switch r.URL.Path {
+		case "/profiles/abc123/privacy/natives":
+			resp = `{"data": [{"id": "apple"}, {"id": "windows"}]}`
+		case "/privacy/natives":
+			resp = `{"data": [{"id": "alexa"}, {"id": "apple"}, {"id": "huawei"}, {"id": "windows"}]}`
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	enabled, available, err := client.PrivacyNatives.Status(ctx, "abc123")
+
+	c.NoErr(err)
+	c.Equal(enabled, []string{"apple", "windows"})
+	c.Equal(available, []string{"alexa", "huawei"})
+}
+
+func TestPrivacyNativesStatusCanceled(t *testing.T) {
+	c := is.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		cancel()
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": [{"id": "apple"}]}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	_, _, err = client.PrivacyNatives.Status(ctx, "abc123")
+
+	c.True(err != nil)
+	c.Equal(calls, 1) // the catalog is not fetched once the context is canceled
+}
+
+func TestPrivacyNativesListWithMeta(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/privacy/natives")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": [{"id": "apple"}, {"id": "windows"}]}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	response, err := client.PrivacyNatives.ListWithMeta(ctx, &ListPrivacyNativesRequest{ProfileID: "abc123"})
+
+	c.NoErr(err)
+	c.Equal(response.Count, 2)
+	c.Equal(len(response.Data), 2)
+	c.Equal(response.Cursor, "")
+}
@@ -0,0 +1,33 @@
+package nextdns
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestPrivacySetDisguisedTrackers(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PATCH")
+		c.Equal(r.URL.Path, "/profiles/abc123/privacy")
+
+		body, err := io.ReadAll(r.Body)
+		c.NoErr(err)
+		c.Equal(string(body), "{\"disguisedTrackers\":false}\n")
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	err = client.Privacy.SetDisguisedTrackers(context.Background(), "abc123", false)
+	c.NoErr(err)
+}
@@ -1,15 +1,23 @@
 package nextdns
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 )
 
 // profilesService is the HTTP path for the profiles API.
 const profilesAPIPath = "profiles"
 
+// profilesDeleteConcurrency is the maximum number of concurrent requests made by DeleteMany.
+const profilesDeleteConcurrency = 4
+
 // CreateProfileRequest encapsulates the request for creating a new profile.
 type CreateProfileRequest struct {
 	Name            string           `json:"name,omitempty"`
@@ -20,6 +28,12 @@ type CreateProfileRequest struct {
 	Allowlist       []*Allowlist     `json:"allowlist,omitempty"`
 	Settings        *Settings        `json:"settings,omitempty"`
 	Rewrites        []*Rewrites      `json:"rewrites,omitempty"`
+
+	// FetchAfterCreate makes CreateAndGet fetch the full profile after creating it.
+	FetchAfterCreate bool `json:"-"`
+
+	// ValidateBeforeCreate makes Create validate the request before sending it (see Validate).
+	ValidateBeforeCreate bool `json:"-"`
 }
 
 // UpdateProfileRequest encapsulates the request for setting custom profile settings.
@@ -38,6 +52,12 @@ type ListProfileRequest struct {
 	Cursor string
 }
 
+// CloneProfileRequest encapsulates the request for cloning a profile.
+type CloneProfileRequest struct {
+	SourceProfileID string
+	NewName         string // Name of the new profile, the source profile name if empty
+}
+
 // DeleteProfileRequest encapsulates the request for deleting a profile.
 type DeleteProfileRequest struct {
 	ProfileID string
@@ -46,10 +66,25 @@ type DeleteProfileRequest struct {
 // ProfilesService is an interface for communicating with the NextDNS API.
 type ProfilesService interface {
 	Create(context.Context, *CreateProfileRequest) (string, error)
+	CreateAndGet(context.Context, *CreateProfileRequest) (string, *Profile, error)
+	CreateFromTemplate(ctx context.Context, template *ProfileTemplate, overrides map[string]any) (string, error)
+	Clone(context.Context, *CloneProfileRequest) (string, error)
 	Get(context.Context, *GetProfileRequest) (*Profile, error)
+	GetRaw(ctx context.Context, profileID string) (map[string]json.RawMessage, error)
 	Update(context.Context, *UpdateProfileRequest) error
+	Replace(ctx context.Context, profileID string, profile *Profile) error
 	List(context.Context, *ListProfileRequest) (*ListProfilesResponse, error)
 	Delete(context.Context, *DeleteProfileRequest) error
+	DeleteMany(ctx context.Context, ids []string) error
+	FindByName(context.Context, string) (*Profiles, error)
+	NameExists(context.Context, string) (bool, error)
+	ListAll(context.Context) ([]*Profiles, error)
+	Map(context.Context) (map[string]*Profiles, error)
+	AuditRetention(ctx context.Context, maxDays int) ([]string, error)
+	Watch(ctx context.Context, profileID string, interval time.Duration, fn func(old, new *Profile)) error
+	StreamDevices(ctx context.Context, profileID string, interval time.Duration) (<-chan *LogDevice, <-chan error)
+	ExportYAML(ctx context.Context, profileID string, w io.Writer) error
+	ImportYAML(r io.Reader) (*CreateProfileRequest, error)
 }
 
 // Profile represents a NextDNS profile.
@@ -85,14 +120,15 @@ type profileResponse struct {
 	Profile *Profile `json:"data"`
 }
 
+// rawProfileResponse represents the response for the profile from the NextDNS API, with untyped fields.
+type rawProfileResponse struct {
+	Profile map[string]json.RawMessage `json:"data"`
+}
+
 // profilesResponse represents the response for listing the profiles from the NextDNS API.
 type profilesResponse struct {
 	Profiles []*Profiles `json:"data"`
-	Metadata struct {
-		Pagination struct {
-			Cursor string `json:"cursor"`
-		} `json:"pagination"`
-	} `json:"meta,omitempty"`
+	listMeta
 	Errors ErrorResponse `json:"errors,omitempty"`
 }
 
@@ -104,7 +140,7 @@ type ListProfilesResponse struct {
 
 // profilesService represents the NextDNS profiles service.
 type profilesService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ ProfilesService = &profilesService{}
@@ -113,7 +149,7 @@ var _ ProfilesService = &profilesService{}
 // nolint: revive
 func NewProfilesService(client *Client) *profilesService {
 	return &profilesService{
-		client: client,
+		client: newServiceClient(client, ServiceProfiles),
 	}
 }
 
@@ -141,12 +177,76 @@ func (s *profilesService) List(ctx context.Context, request *ListProfileRequest)
 
 	return &ListProfilesResponse{
 		Profiles: response.Profiles,
-		Cursor:   response.Metadata.Pagination.Cursor,
+		Cursor:   response.cursor(),
 	}, nil
 }
 
+// ListAll returns all the profiles, walking through all the pages.
+// If the context is canceled mid-pagination, the profiles collected so far are returned alongside the context error.
+func (s *profilesService) ListAll(ctx context.Context) ([]*Profiles, error) {
+	profiles, err := collectPages(ctx, func(cursor string) ([]*Profiles, string, error) {
+		response, err := s.List(ctx, &ListProfileRequest{Cursor: cursor})
+		if err != nil {
+			return nil, "", err
+		}
+		return response.Profiles, response.Cursor, nil
+	})
+	if err != nil {
+		return profiles, fmt.Errorf("error listing all the profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// Map returns all the profiles keyed by their ID, walking through all the pages.
+// It's useful to look up the profiles referenced by ID, e.g. in logs or analytics.
+func (s *profilesService) Map(ctx context.Context) (map[string]*Profiles, error) {
+	profiles, err := s.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*Profiles, len(profiles))
+	for _, profile := range profiles {
+		byID[profile.ID] = profile
+	}
+
+	return byID, nil
+}
+
+// AuditRetention returns the IDs of the profiles keeping their logs for more than maxDays days
+// (see SettingsLogs.MeetsRetentionPolicy). The logs settings of every profile are fetched one
+// after the other, after walking through all the pages of profiles.
+func (s *profilesService) AuditRetention(ctx context.Context, maxDays int) ([]string, error) {
+	profiles, err := s.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settingsLogs := NewSettingsLogsService(s.client.Client)
+	var ids []string
+	for _, profile := range profiles {
+		logs, err := settingsLogs.Get(ctx, &GetSettingsLogsRequest{ProfileID: profile.ID})
+		if err != nil {
+			return nil, fmt.Errorf("error auditing the logs retention of the profile %s: %w", profile.ID, err)
+		}
+
+		if logs != nil && !logs.MeetsRetentionPolicy(maxDays) {
+			ids = append(ids, profile.ID)
+		}
+	}
+
+	return ids, nil
+}
+
 // Create creates a profile and returns a profile ID.
 func (s *profilesService) Create(ctx context.Context, request *CreateProfileRequest) (string, error) {
+	if request.ValidateBeforeCreate {
+		if err := request.Validate(); err != nil {
+			return "", fmt.Errorf("error validating request to create a profile: %w", err)
+		}
+	}
+
 	req, err := s.client.newRequest(http.MethodPost, profilesAPIPath, request)
 	if err != nil {
 		return "", fmt.Errorf("error creating request to create a profile: %w", err)
@@ -161,16 +261,79 @@ func (s *profilesService) Create(ctx context.Context, request *CreateProfileRequ
 	return response.Profile.ID, nil
 }
 
+// Clone creates a new profile with the configuration of the source profile, and returns the new
+// profile ID. The read-only fields (fingerprint, setup, blocklists metadata) aren't copied.
+func (s *profilesService) Clone(ctx context.Context, request *CloneProfileRequest) (string, error) {
+	profile, err := s.Get(ctx, &GetProfileRequest{ProfileID: request.SourceProfileID})
+	if err != nil {
+		return "", fmt.Errorf("error cloning the profile %s: %w", request.SourceProfileID, err)
+	}
+
+	create := newCreateProfileRequest(profile)
+	if request.NewName != "" {
+		create.Name = request.NewName
+	}
+	if create.Privacy != nil {
+		privacy := *create.Privacy
+		privacy.Blocklists = make([]*PrivacyBlocklists, len(create.Privacy.Blocklists))
+		for i, blocklist := range create.Privacy.Blocklists {
+			privacy.Blocklists[i] = &PrivacyBlocklists{ID: blocklist.ID}
+		}
+		create.Privacy = &privacy
+	}
+
+	id, err := s.Create(ctx, create)
+	if err != nil {
+		return "", fmt.Errorf("error cloning the profile %s: %w", request.SourceProfileID, err)
+	}
+
+	return id, nil
+}
+
+// newCreateProfileRequest returns a request creating a profile with the configuration of the
+// given profile. The fields not accepted when creating a profile (fingerprint, setup) are left out.
+func newCreateProfileRequest(profile *Profile) *CreateProfileRequest {
+	return &CreateProfileRequest{
+		Name:            profile.Name,
+		Security:        profile.Security,
+		Privacy:         profile.Privacy,
+		ParentalControl: profile.ParentalControl,
+		Denylist:        profile.Denylist,
+		Allowlist:       profile.Allowlist,
+		Settings:        profile.Settings,
+		Rewrites:        profile.Rewrites,
+	}
+}
+
+// CreateAndGet creates a profile and returns its ID. When FetchAfterCreate is set in the request,
+// the full profile is fetched with a follow-up request and returned too; otherwise the profile is nil.
+func (s *profilesService) CreateAndGet(ctx context.Context, request *CreateProfileRequest) (string, *Profile, error) {
+	id, err := s.Create(ctx, request)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !request.FetchAfterCreate {
+		return id, nil, nil
+	}
+
+	profile, err := s.Get(ctx, &GetProfileRequest{ProfileID: id})
+	if err != nil {
+		return id, nil, err
+	}
+
+	return id, profile, nil
+}
+
 // Update updates the settings of a profile.
 func (s *profilesService) Update(ctx context.Context, request *UpdateProfileRequest) error {
-	path := fmt.Sprintf("%s/%s", profilesAPIPath, request.ProfileID)
+	path := profileAPIPath(request.ProfileID)
 	req, err := s.client.newRequest(http.MethodPatch, path, request.Profile)
 	if err != nil {
 		return fmt.Errorf("error creating request to update the profile: %w", err)
 	}
 
-	response := profileResponse{}
-	err = s.client.do(ctx, req, &response)
+	err = s.client.do(ctx, req, nil)
 	if err != nil {
 		return fmt.Errorf("error making a request to update the profile: %w", err)
 	}
@@ -178,9 +341,73 @@ func (s *profilesService) Update(ctx context.Context, request *UpdateProfileRequ
 	return nil
 }
 
+// Replace replaces the whole configuration of a profile with the given one, for a declarative
+// sync. The NextDNS API has no PUT for a whole profile: the objects (name, security, privacy,
+// parental control, settings) are sent with a single PATCH, then each list (denylist, allowlist,
+// rewrites) is replaced with a PUT, an absent list being emptied. The read-only fields
+// (fingerprint, setup) are ignored. The rewrites are checked before making any request, and the
+// requests stop at the first failure, which may leave the profile partially replaced.
+func (s *profilesService) Replace(ctx context.Context, profileID string, profile *Profile) error {
+	var errs []error
+	for i, rewrite := range profile.Rewrites {
+		errs = append(errs, validateRewrite(fmt.Sprintf("rewrites[%d]", i), rewrite)...)
+	}
+	if err := newRewritesValidationError(errs); err != nil {
+		return fmt.Errorf("error validating request to replace the profile: %w", err)
+	}
+
+	path := profileAPIPath(profileID)
+	body := &Profile{
+		Name:            profile.Name,
+		Security:        profile.Security,
+		Privacy:         profile.Privacy,
+		ParentalControl: profile.ParentalControl,
+		Settings:        profile.Settings,
+	}
+	req, err := s.client.newRequest(http.MethodPatch, path, body)
+	if err != nil {
+		return fmt.Errorf("error creating request to replace the profile: %w", err)
+	}
+
+	err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return fmt.Errorf("error making a request to replace the profile: %w", err)
+	}
+
+	lists := []struct {
+		path string
+		list any
+	}{
+		{denylistAPIPath, emptyIfNil(profile.Denylist)},
+		{allowlistAPIPath, emptyIfNil(profile.Allowlist)},
+		{rewritesAPIPath, emptyIfNil(profile.Rewrites)},
+	}
+	for _, l := range lists {
+		req, err := s.client.newRequest(http.MethodPut, fmt.Sprintf("%s/%s", path, l.path), l.list)
+		if err != nil {
+			return fmt.Errorf("error creating request to replace the profile %s: %w", l.path, err)
+		}
+
+		err = s.client.do(ctx, req, nil)
+		if err != nil {
+			return fmt.Errorf("error making a request to replace the profile %s: %w", l.path, err)
+		}
+	}
+
+	return nil
+}
+
+// emptyIfNil returns an empty slice for a nil one, so that it's encoded as an empty JSON array.
+func emptyIfNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
 // Get returns a profile.
 func (s *profilesService) Get(ctx context.Context, request *GetProfileRequest) (*Profile, error) {
-	path := fmt.Sprintf("%s/%s", profilesAPIPath, request.ProfileID)
+	path := profileAPIPath(request.ProfileID)
 	req, err := s.client.newRequest(http.MethodGet, path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error creating request to get the profile: %w", err)
@@ -195,9 +422,26 @@ func (s *profilesService) Get(ctx context.Context, request *GetProfileRequest) (
 	return response.Profile, nil
 }
 
+// GetRaw returns the top-level fields of a profile without decoding them, including the ones
+// not modeled by Profile yet.
+func (s *profilesService) GetRaw(ctx context.Context, profileID string) (map[string]json.RawMessage, error) {
+	req, err := s.client.newRequest(http.MethodGet, profileAPIPath(profileID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to get the raw profile: %w", err)
+	}
+
+	response := rawProfileResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error making a request to get the raw profile: %w", err)
+	}
+
+	return response.Profile, nil
+}
+
 // Delete deletes a profile.
 func (s *profilesService) Delete(ctx context.Context, request *DeleteProfileRequest) error {
-	path := fmt.Sprintf("%s/%s", profilesAPIPath, request.ProfileID)
+	path := profileAPIPath(request.ProfileID)
 	req, err := s.client.newRequest(http.MethodDelete, path, nil)
 	if err != nil {
 		return fmt.Errorf("error creating request to delete the profile: %w", err)
@@ -211,7 +455,115 @@ func (s *profilesService) Delete(ctx context.Context, request *DeleteProfileRequ
 	return err
 }
 
-// profileAPIPath returns the profile API path.
+// DeleteMany deletes multiple profiles concurrently. The profiles already deleted (404 responses)
+// are considered deleted successfully. Once the context is canceled, no new deletion is started.
+// The errors of the profiles that failed, or weren't deleted because of the cancellation, are
+// joined in the returned error.
+func (s *profilesService) DeleteMany(ctx context.Context, ids []string) error {
+	errs := forEachConcurrent(ctx, ids, profilesDeleteConcurrency, func(_ int, id string) error {
+		err := s.Delete(ctx, &DeleteProfileRequest{ProfileID: id})
+		if IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+
+	return joinIDErrors("profile", ids, errs)
+}
+
+// FindByName returns the first profile with the given name, walking through all the pages.
+// It returns nil if no profile matches the name.
+func (s *profilesService) FindByName(ctx context.Context, name string) (*Profiles, error) {
+	request := &ListProfileRequest{}
+	for {
+		response, err := s.List(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("error finding the profile by name %q: %w", name, err)
+		}
+
+		for _, p := range response.Profiles {
+			if p.Name == name {
+				return p, nil
+			}
+		}
+
+		if response.Cursor == "" {
+			return nil, nil
+		}
+		request.Cursor = response.Cursor
+	}
+}
+
+// NameExists reports whether a profile with the given name already exists.
+func (s *profilesService) NameExists(ctx context.Context, name string) (bool, error) {
+	profile, err := s.FindByName(ctx, name)
+	if err != nil {
+		return false, err
+	}
+
+	return profile != nil, nil
+}
+
+// Watch polls a profile at the given interval and calls fn with the previous and the current
+// profile every time its serialized form changes. It blocks until the context is canceled,
+// returning the context error, or until a poll fails. The interval must be positive.
+func (s *profilesService) Watch(ctx context.Context, profileID string, interval time.Duration, fn func(old, new *Profile)) error {
+	if interval <= 0 {
+		return fmt.Errorf("error watching the profile %s: invalid interval %s: must be positive", profileID, interval)
+	}
+
+	request := &GetProfileRequest{ProfileID: profileID}
+
+	current, err := s.Get(ctx, request)
+	if err != nil {
+		return fmt.Errorf("error watching the profile %s: %w", profileID, err)
+	}
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return fmt.Errorf("error watching the profile %s: %w", profileID, err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		profile, err := s.Get(ctx, request)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return fmt.Errorf("error watching the profile %s: %w", profileID, err)
+		}
+		profileJSON, err := json.Marshal(profile)
+		if err != nil {
+			return fmt.Errorf("error watching the profile %s: %w", profileID, err)
+		}
+
+		if !bytes.Equal(currentJSON, profileJSON) {
+			fn(current, profile)
+			current, currentJSON = profile, profileJSON
+		}
+	}
+}
+
+// profileAPIPath returns the profile API path. Every path under a profile is built from it, so the
+// profile ID is always escaped as a single path segment.
 func profileAPIPath(profile string) string {
-	return fmt.Sprintf("%s/%s", profilesAPIPath, profile)
+	return fmt.Sprintf("%s/%s", profilesAPIPath, pathSegment(profile))
+}
+
+// pathSegment escapes a value to be used as a single segment of an API path. The dot segments are
+// escaped too, since they would otherwise be resolved against the base URL.
+func pathSegment(value string) string {
+	escaped := url.PathEscape(value)
+	if escaped == "." || escaped == ".." {
+		return strings.ReplaceAll(escaped, ".", "%2E")
+	}
+	return escaped
 }
@@ -0,0 +1,94 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// StreamDevices emits the devices newly seen by a profile on the returned channel. The NextDNS API
+// has no push or long-poll endpoint for profile changes, so the devices analytics are polled at the
+// given interval: the devices of the first poll are the baseline, and every device whose ID wasn't
+// seen by a previous poll is emitted afterwards. Both channels are closed when the context is
+// canceled, or after a failing poll sends its error on the error channel. An interval that isn't
+// positive sends its error on the error channel without polling.
+func (s *profilesService) StreamDevices(ctx context.Context, profileID string, interval time.Duration) (<-chan *LogDevice, <-chan error) {
+	devices := make(chan *LogDevice)
+	errs := make(chan error, 1)
+
+	if interval <= 0 {
+		errs <- fmt.Errorf("error streaming the devices of the profile %s: invalid interval %s: must be positive", profileID, interval)
+		close(errs)
+		close(devices)
+		return devices, errs
+	}
+
+	analytics := NewAnalyticsService(s.client.Client)
+
+	poll := func() ([]*AnalyticsDeviceEntry, error) {
+		entries, err := collectPages(ctx, func(cursor string) ([]*AnalyticsDeviceEntry, string, error) {
+			response, err := analytics.GetDevices(ctx, &GetAnalyticsRequest{
+				ProfileID: profileID,
+				Options:   &AnalyticsOptions{Limit: 500, Cursor: cursor},
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			return response.Data, response.Pagination.Cursor, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error streaming the devices of the profile %s: %w", profileID, err)
+		}
+		return entries, nil
+	}
+
+	go func() {
+		defer close(errs)
+		defer close(devices)
+
+		entries, err := poll()
+		if err != nil {
+			errs <- err
+			return
+		}
+		seen := make(map[string]struct{}, len(entries))
+		for _, entry := range entries {
+			seen[entry.ID] = struct{}{}
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			entries, err := poll()
+			if err != nil {
+				if ctx.Err() == nil {
+					errs <- err
+				}
+				return
+			}
+
+			for _, entry := range entries {
+				if _, ok := seen[entry.ID]; ok {
+					continue
+				}
+				seen[entry.ID] = struct{}{}
+
+				device := &LogDevice{ID: entry.ID, Name: entry.Name, Model: entry.Model}
+				select {
+				case devices <- device:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return devices, errs
+}
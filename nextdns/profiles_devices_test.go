@@ -0,0 +1,88 @@
+package nextdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestProfilesStreamDevices(t *testing.T) {
+	c := is.New(t)
+
+	var polls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/analytics/devices")
+		c.Equal(r.URL.Query().Get("limit"), "500")
+		polls++
+
+		// The phone shows up from the second poll onwards.
+		resp := `{"data": [{"id": "laptop", "name": "Laptop", "queries": 10}], "meta": {"pagination": {"cursor": null}}}`
+		if polls >= 2 {
+			resp = `{"data": [{"id": "laptop", "name": "Laptop", "queries": 12}, {"id": "phone", "name": "Phone", "model": "iPhone", "queries": 1}], "meta": {"pagination": {"cursor": null}}}`
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	devices, errs := client.Profiles.StreamDevices(ctx, "abc123", 10*time.Millisecond)
+
+	device := <-devices
+	c.Equal(device, &LogDevice{ID: "phone", Name: "Phone", Model: "iPhone"})
+
+	cancel()
+	for range devices {
+		c.Fail() // No device is seen after the phone.
+	}
+	c.NoErr(<-errs)
+}
+
+func TestProfilesStreamDevicesError(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(`{"errors": [{"code": "notFound"}]}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	devices, errs := client.Profiles.StreamDevices(context.Background(), "abc123", 10*time.Millisecond)
+
+	_, ok := <-devices
+	c.True(!ok)
+	c.True(<-errs != nil)
+}
+
+func TestProfilesStreamDevicesInvalidInterval(t *testing.T) {
+	c := is.New(t)
+
+	client, err := New(WithBaseURL("http://localhost"))
+	c.NoErr(err)
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		devices, errs := client.Profiles.StreamDevices(context.Background(), "abc123", interval)
+
+		_, ok := <-devices
+		c.True(!ok)
+		c.True(<-errs != nil)
+		_, ok = <-errs
+		c.True(!ok)
+	}
+}
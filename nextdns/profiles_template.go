@@ -0,0 +1,65 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+)
+
+// Override points supported by profile templates.
+const (
+	TemplateOverrideName         = "name"          // Profile name (string).
+	TemplateOverrideLogsLocation = "logs.location" // Logs storage location (string), e.g. "us" or "eu".
+)
+
+// ProfileTemplate represents a reusable preset for provisioning similar profiles.
+type ProfileTemplate struct {
+	Profile *CreateProfileRequest
+}
+
+// build returns the create request of the template with the overrides applied.
+// The template itself is never modified.
+func (t *ProfileTemplate) build(overrides map[string]any) (*CreateProfileRequest, error) {
+	request := &CreateProfileRequest{}
+	if t != nil && t.Profile != nil {
+		*request = *t.Profile
+	}
+
+	for key, value := range overrides {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid template override %q: expected a string, got %T", key, value)
+		}
+
+		switch key {
+		case TemplateOverrideName:
+			request.Name = s
+		case TemplateOverrideLogsLocation:
+			settings := &Settings{}
+			if request.Settings != nil {
+				*settings = *request.Settings
+			}
+			logs := &SettingsLogs{}
+			if settings.Logs != nil {
+				*logs = *settings.Logs
+			}
+			logs.Location = s
+			settings.Logs = logs
+			request.Settings = settings
+		default:
+			return nil, fmt.Errorf("unknown template override %q", key)
+		}
+	}
+
+	return request, nil
+}
+
+// CreateFromTemplate creates a profile from a template, applying the overrides on top of it,
+// and returns the profile ID. See the TemplateOverride constants for the supported overrides.
+func (s *profilesService) CreateFromTemplate(ctx context.Context, template *ProfileTemplate, overrides map[string]any) (string, error) {
+	request, err := template.build(overrides)
+	if err != nil {
+		return "", fmt.Errorf("error building the profile from the template: %w", err)
+	}
+
+	return s.Create(ctx, request)
+}
@@ -0,0 +1,81 @@
+package nextdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestProfilesCreateFromTemplate(t *testing.T) {
+	c := is.New(t)
+
+	var created []*CreateProfileRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "POST")
+		c.Equal(r.URL.Path, "/profiles")
+
+		request := &CreateProfileRequest{}
+		c.NoErr(json.NewDecoder(r.Body).Decode(request))
+		created = append(created, request)
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": {"id": "abc123"}}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	template := &ProfileTemplate{
+		Profile: &CreateProfileRequest{
+			Name:     "template",
+			Security: &Security{AiThreatDetection: true},
+			Settings: &Settings{
+				Logs: &SettingsLogs{Enabled: true, Location: "us"},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	_, err = client.Profiles.CreateFromTemplate(ctx, template, map[string]any{
+		TemplateOverrideName: "Kids",
+	})
+	c.NoErr(err)
+
+	_, err = client.Profiles.CreateFromTemplate(ctx, template, map[string]any{
+		TemplateOverrideName:         "Office",
+		TemplateOverrideLogsLocation: "eu",
+	})
+	c.NoErr(err)
+
+	c.Equal(len(created), 2)
+	c.Equal(created[0].Name, "Kids")
+	c.Equal(created[0].Settings.Logs.Location, "us")
+	c.True(created[0].Security.AiThreatDetection)
+	c.Equal(created[1].Name, "Office")
+	c.Equal(created[1].Settings.Logs.Location, "eu")
+	c.True(created[1].Security.AiThreatDetection)
+
+	// The template is left untouched.
+	c.Equal(template.Profile.Name, "template")
+	c.Equal(template.Profile.Settings.Logs.Location, "us")
+}
+
+func TestProfilesCreateFromTemplateInvalidOverride(t *testing.T) {
+	c := is.New(t)
+
+	client, err := New()
+	c.NoErr(err)
+
+	ctx := context.Background()
+	_, err = client.Profiles.CreateFromTemplate(ctx, &ProfileTemplate{}, map[string]any{"unknown": "value"})
+	c.True(err != nil)
+
+	_, err = client.Profiles.CreateFromTemplate(ctx, &ProfileTemplate{}, map[string]any{TemplateOverrideName: 42})
+	c.True(err != nil)
+}
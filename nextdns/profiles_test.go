@@ -2,9 +2,15 @@ package nextdns
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/matryer/is"
 )
@@ -115,3 +121,526 @@ func TestProfilesListNilRequest(t *testing.T) {
 	c.Equal(len(response.Profiles), 0)
 	c.Equal(response.Cursor, "")
 }
+
+func TestProfilesNameExists(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles")
+
+		var resp string
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			resp = `{"data": [{"id": "abc123", "fingerprint": "fp123", "name": "Home"}], "meta": {"pagination": {"cursor": "page2"}}}`
+		case "page2":
+			resp = `{"data": [{"id": "def456", "fingerprint": "fp456", "name": "Office"}], "meta": {"pagination": {"cursor": ""}}}`
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+
+	exists, err := client.Profiles.NameExists(ctx, "Office")
+	c.NoErr(err)
+	c.True(exists)
+
+	exists, err = client.Profiles.NameExists(ctx, "Garage")
+	c.NoErr(err)
+	c.True(!exists)
+
+	profile, err := client.Profiles.FindByName(ctx, "Office")
+	c.NoErr(err)
+	c.Equal(profile.ID, "def456")
+}
+
+func TestProfilesListAll(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp string
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			resp = `{"data": [{"id": "abc123", "name": "Home"}], "meta": {"pagination": {"cursor": "page2"}}}`
+		case "page2":
+			resp = `{"data": [{"id": "def456", "name": "Office"}], "meta": {"pagination": {"cursor": ""}}}`
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	profiles, err := client.Profiles.ListAll(context.Background())
+	c.NoErr(err)
+	c.Equal(len(profiles), 2)
+	c.Equal(profiles[1].ID, "def456")
+}
+
+func TestProfilesListAllCanceled(t *testing.T) {
+	c := is.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "page2" {
+			// Cancels the pagination while the second page is in flight.
+			cancel()
+			<-r.Context().Done()
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{"data": [{"id": "abc123", "name": "Home"}], "meta": {"pagination": {"cursor": "page2"}}}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	profiles, err := client.Profiles.ListAll(ctx)
+	c.True(errors.Is(err, context.Canceled))
+	c.Equal(len(profiles), 1)
+	c.Equal(profiles[0].ID, "abc123")
+}
+
+func TestProfilesWatch(t *testing.T) {
+	c := is.New(t)
+
+	var polls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.URL.Path, "/profiles/abc123")
+		polls++
+
+		// The profile is renamed from the third poll onwards.
+		resp := `{"data": {"name": "Before", "fingerprint": "fp123"}}`
+		if polls >= 3 {
+			resp = `{"data": {"name": "After", "fingerprint": "fp123"}}`
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var changes int
+	err = client.Profiles.Watch(ctx, "abc123", 10*time.Millisecond, func(old, new *Profile) {
+		changes++
+		c.Equal(old.Name, "Before")
+		c.Equal(new.Name, "After")
+		cancel()
+	})
+
+	c.True(errors.Is(err, context.Canceled))
+	c.Equal(changes, 1)
+}
+
+func TestProfilesWatchInvalidInterval(t *testing.T) {
+	c := is.New(t)
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	err = client.Profiles.Watch(context.Background(), "abc123", 0, func(_, _ *Profile) {})
+	c.True(err != nil)
+	c.Equal(calls, 0)
+}
+
+func TestProfilesCreateNestedParameterError(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "POST")
+		c.Equal(r.URL.Path, "/profiles")
+
+		w.WriteHeader(http.StatusBadRequest)
+		resp := `{"errors": [{"code": "invalid", "detail": "Invalid retention", "source": {"parameter": "settings.logs.retention"}}]}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	_, err = client.Profiles.Create(ctx, &CreateProfileRequest{
+		Name: "nested",
+		Settings: &Settings{
+			Logs: &SettingsLogs{Enabled: true, Retention: 42},
+		},
+	})
+	c.True(err != nil)
+
+	var apiErr *APIError
+	c.True(errors.As(err, &apiErr))
+	c.Equal(apiErr.Code, "invalid")
+	c.Equal(apiErr.Parameter, "settings.logs.retention")
+	c.Equal(apiErr.Field(), "retention")
+}
+
+func TestProfilesCreateAndGet(t *testing.T) {
+	c := is.New(t)
+
+	var gets int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp string
+		switch r.Method {
+		case "POST":
+			c.Equal(r.URL.Path, "/profiles")
+			resp = `{"data": {"id": "abc123"}}`
+		case "GET":
+			gets++
+			c.Equal(r.URL.Path, "/profiles/abc123")
+			resp = `{"data": {"name": "Created", "fingerprint": "fp123"}}`
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+
+	id, profile, err := client.Profiles.CreateAndGet(ctx, &CreateProfileRequest{Name: "Created"})
+	c.NoErr(err)
+	c.Equal(id, "abc123")
+	c.True(profile == nil)
+	c.Equal(gets, 0)
+
+	id, profile, err = client.Profiles.CreateAndGet(ctx, &CreateProfileRequest{Name: "Created", FetchAfterCreate: true})
+	c.NoErr(err)
+	c.Equal(id, "abc123")
+	c.Equal(profile.Name, "Created")
+	c.Equal(gets, 1)
+}
+
+func TestProfilesGetRaw(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{"data": {"name": "My Profile", "fingerprint": "fp123", "futureFeature": {"enabled": true}}}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	raw, err := client.Profiles.GetRaw(context.Background(), "abc123")
+	c.NoErr(err)
+	c.Equal(len(raw), 3)
+	c.Equal(string(raw["name"]), `"My Profile"`)
+
+	var feature struct {
+		Enabled bool `json:"enabled"`
+	}
+	c.NoErr(json.Unmarshal(raw["futureFeature"], &feature))
+	c.True(feature.Enabled)
+}
+
+func TestProfileAPIPathEscaping(t *testing.T) {
+	c := is.New(t)
+
+	tests := []struct {
+		profileID string
+		want      string
+	}{
+		{profileID: "abc123", want: "/profiles/abc123/logs"},
+		{profileID: "a/b?c#d", want: "/profiles/a%2Fb%3Fc%23d/logs"},
+		{profileID: "a b%", want: "/profiles/a%20b%25/logs"},
+		{profileID: "..", want: "/profiles/%2E%2E/logs"},
+	}
+
+	for _, tt := range tests {
+		var path string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.EscapedPath()
+
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"data": []}`))
+			c.NoErr(err)
+		}))
+
+		client, err := New(WithBaseURL(ts.URL))
+		c.NoErr(err)
+
+		_, err = client.Logs.Get(context.Background(), &GetLogsRequest{ProfileID: tt.profileID})
+		ts.Close()
+
+		c.NoErr(err)
+		c.Equal(path, tt.want)
+		c.Equal(analyticsPath(tt.profileID, "status"), strings.TrimSuffix(tt.want[1:], "logs")+"analytics/status")
+	}
+}
+
+func TestProfilesMap(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp string
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			resp = `{"data": [{"id": "abc123", "fingerprint": "fp1", "name": "Home"}], "meta": {"pagination": {"cursor": "page2"}}}`
+		case "page2":
+			resp = `{"data": [{"id": "def456", "fingerprint": "fp2", "name": "Office"}], "meta": {"pagination": {"cursor": ""}}}`
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	profiles, err := client.Profiles.Map(context.Background())
+	c.NoErr(err)
+	c.Equal(profiles, map[string]*Profiles{
+		"abc123": {ID: "abc123", Fingerprint: "fp1", Name: "Home"},
+		"def456": {ID: "def456", Fingerprint: "fp2", Name: "Office"},
+	})
+}
+
+func TestProfilesAuditRetention(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+
+		var resp string
+		switch r.URL.Path {
+		case "/profiles":
+			resp = `{"data": [{"id": "abc123", "name": "Home"}, {"id": "def456", "name": "Office"}, {"id": "ghi789", "name": "Kids"}]}`
+		case "/profiles/abc123/settings/logs":
+			resp = `{"data": {"enabled": true, "retention": 604800}}`
+		case "/profiles/def456/settings/logs":
+			resp = `{"data": {"enabled": true, "retention": 31536000}}`
+		case "/profiles/ghi789/settings/logs":
+			resp = `{"data": {"enabled": false, "retention": 63072000}}`
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ids, err := client.Profiles.AuditRetention(context.Background(), 30)
+	c.NoErr(err)
+	c.Equal(ids, []string{"def456"})
+}
+
+func TestProfilesClone(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			c.Equal(r.URL.Path, "/profiles/abc123")
+
+			w.WriteHeader(http.StatusOK)
+			resp := `{"data": {
+				"name": "Home",
+				"fingerprint": "fp04d207c439ee4858",
+				"security": {"threatIntelligenceFeeds": true, "cryptojacking": true, "tlds": [{"id": "ru"}]},
+				"privacy": {"blocklists": [{"id": "nextdns-recommended", "name": "NextDNS Ads & Trackers Blocklist", "entries": 131564, "updatedOn": "2024-01-15T10:30:00Z"}], "natives": [{"id": "apple"}], "disguisedTrackers": true},
+				"setup": {"ipv4": ["45.90.28.0"]}
+			}}`
+			_, err := w.Write([]byte(resp))
+			c.NoErr(err)
+		case "POST":
+			c.Equal(r.URL.Path, "/profiles")
+
+			body, err := io.ReadAll(r.Body)
+			c.NoErr(err)
+			var create map[string]json.RawMessage
+			c.NoErr(json.Unmarshal(body, &create))
+
+			c.Equal(string(create["name"]), `"Home (copy)"`)
+			c.Equal(string(create["security"]), `{"threatIntelligenceFeeds":true,"aiThreatDetection":false,"googleSafeBrowsing":false,"cryptojacking":true,"dnsRebinding":false,"idnHomographs":false,"typosquatting":false,"dga":false,"nrd":false,"ddns":false,"parking":false,"csam":false,"tlds":[{"id":"ru"}]}`)
+			c.Equal(string(create["privacy"]), `{"blocklists":[{"id":"nextdns-recommended"}],"natives":[{"id":"apple"}],"disguisedTrackers":true,"allowAffiliate":false}`)
+			_, ok := create["fingerprint"]
+			c.True(!ok)
+			_, ok = create["setup"]
+			c.True(!ok)
+
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write([]byte(`{"data": {"id": "def456"}}`))
+			c.NoErr(err)
+		default:
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	id, err := client.Profiles.Clone(context.Background(), &CloneProfileRequest{
+		SourceProfileID: "abc123",
+		NewName:         "Home (copy)",
+	})
+	c.NoErr(err)
+	c.Equal(id, "def456")
+}
+
+func TestProfilesDeleteMany(t *testing.T) {
+	c := is.New(t)
+
+	var mu sync.Mutex
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "DELETE")
+
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/profiles/def456":
+			// The profile is already deleted.
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"errors": [{"code": "notFound"}]}`))
+			c.NoErr(err)
+		case "/profiles/ghi789":
+			w.WriteHeader(http.StatusForbidden)
+			_, err := w.Write([]byte(`{"errors": [{"code": "forbidden"}]}`))
+			c.NoErr(err)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	err = client.Profiles.DeleteMany(context.Background(), []string{"abc123", "def456"})
+	c.NoErr(err)
+
+	err = client.Profiles.DeleteMany(context.Background(), []string{"abc123", "def456", "ghi789"})
+	c.True(IsAuthError(err))
+	c.True(strings.Contains(err.Error(), "profile ghi789"))
+	c.Equal(len(paths), 5)
+}
+
+func TestProfilesDeleteManyCanceled(t *testing.T) {
+	c := is.New(t)
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = client.Profiles.DeleteMany(ctx, []string{"abc123", "def456", "ghi789"})
+	c.True(errors.Is(err, context.Canceled))
+	c.Equal(calls, 0)
+}
+
+func TestProfilesReplace(t *testing.T) {
+	c := is.New(t)
+
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		c.NoErr(err)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	err = client.Profiles.Replace(context.Background(), "abc123", &Profile{
+		Name:        "test",
+		Fingerprint: "fp123",
+		Settings:    &Settings{Web3: true},
+		Denylist:    []*Denylist{{ID: "bad.com", Active: true}},
+		Setup:       &Setup{},
+	})
+	c.NoErr(err)
+	c.Equal(requests, []string{
+		"PATCH /profiles/abc123 {\"name\":\"test\",\"settings\":{\"web3\":true,\"bav\":false}}\n",
+		"PUT /profiles/abc123/denylist [{\"id\":\"bad.com\",\"active\":true}]\n",
+		"PUT /profiles/abc123/allowlist []\n",
+		"PUT /profiles/abc123/rewrites []\n",
+	})
+}
+
+func TestProfilesReplaceInvalidRewrites(t *testing.T) {
+	c := is.New(t)
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	err = client.Profiles.Replace(context.Background(), "abc123", &Profile{
+		Name:     "test",
+		Rewrites: []*Rewrites{{Name: "nas.home", Type: "CNAMe", Content: "nas.lan"}},
+	})
+
+	var e *Error
+	c.True(errors.As(err, &e))
+	c.Equal(e.Type, ErrorTypeRequest)
+	c.Equal(calls, 0)
+}
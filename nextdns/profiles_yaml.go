@@ -0,0 +1,69 @@
+package nextdns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExportYAML writes the configuration of a profile to w as YAML, e.g. to keep it in a git
+// repository. The keys have the names and the order of the JSON API, so the output is stable
+// between exports. The fields not accepted when creating a profile (fingerprint, setup) are
+// left out, so the output can be read back with ImportYAML.
+func (s *profilesService) ExportYAML(ctx context.Context, profileID string, w io.Writer) error {
+	profile, err := s.Get(ctx, &GetProfileRequest{ProfileID: profileID})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(newCreateProfileRequest(profile))
+	if err != nil {
+		return fmt.Errorf("error encoding the profile %s: %w", profileID, err)
+	}
+
+	// YAML is a superset of JSON: decoding the JSON document keeps the order of its keys.
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("error converting the profile %s to yaml: %w", profileID, err)
+	}
+	resetYAMLStyle(&document)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return fmt.Errorf("error writing the profile %s as yaml: %w", profileID, err)
+	}
+	return encoder.Close()
+}
+
+// ImportYAML reads a profile configuration written by ExportYAML, and returns the request to
+// create a profile with it.
+func (s *profilesService) ImportYAML(r io.Reader) (*CreateProfileRequest, error) {
+	var document any
+	if err := yaml.NewDecoder(r).Decode(&document); err != nil {
+		return nil, fmt.Errorf("error reading the profile yaml: %w", err)
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("error converting the profile yaml: %w", err)
+	}
+
+	request := &CreateProfileRequest{}
+	if err := json.Unmarshal(data, request); err != nil {
+		return nil, fmt.Errorf("error decoding the profile yaml: %w", err)
+	}
+	return request, nil
+}
+
+// resetYAMLStyle removes the flow and quoting styles of a decoded JSON document, so it's
+// encoded as block-style YAML.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
@@ -0,0 +1,82 @@
+package nextdns
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestProfilesExportImportYAML(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123")
+
+		w.WriteHeader(http.StatusOK)
+		resp := `{"data": {
+			"name": "Home",
+			"fingerprint": "fp04d207c439ee4858",
+			"security": {"threatIntelligenceFeeds": true, "googleSafeBrowsing": true, "tlds": [{"id": "ru"}]},
+			"denylist": [{"id": "ads.example.com", "active": true}, {"id": "true", "active": false}],
+			"settings": {"logs": {"enabled": true, "retention": 7776000}, "web3": true},
+			"rewrites": [{"name": "nas.home", "content": "192.168.1.10"}],
+			"setup": {"ipv4": ["45.90.28.0"]}
+		}}`
+		_, err := w.Write([]byte(resp))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	var out bytes.Buffer
+	err = client.Profiles.ExportYAML(context.Background(), "abc123", &out)
+	c.NoErr(err)
+
+	exported := out.String()
+	c.True(strings.HasPrefix(exported, "name: Home\nsecurity:\n"))
+	c.True(strings.Contains(exported, "  - id: ads.example.com\n    active: true\n"))
+	c.True(strings.Contains(exported, `  - id: "true"`))
+	c.True(!strings.Contains(exported, "fingerprint"))
+	c.True(!strings.Contains(exported, "setup"))
+
+	var again bytes.Buffer
+	c.NoErr(client.Profiles.ExportYAML(context.Background(), "abc123", &again))
+	c.Equal(again.String(), exported) // the key order is stable
+
+	request, err := client.Profiles.ImportYAML(strings.NewReader(exported))
+	c.NoErr(err)
+
+	profile, err := client.Profiles.Get(context.Background(), &GetProfileRequest{ProfileID: "abc123"})
+	c.NoErr(err)
+	c.Equal(request, &CreateProfileRequest{
+		Name:            profile.Name,
+		Security:        profile.Security,
+		Privacy:         profile.Privacy,
+		ParentalControl: profile.ParentalControl,
+		Denylist:        profile.Denylist,
+		Allowlist:       profile.Allowlist,
+		Settings:        profile.Settings,
+		Rewrites:        profile.Rewrites,
+	})
+}
+
+func TestProfilesImportYAMLInvalid(t *testing.T) {
+	c := is.New(t)
+
+	client, err := New()
+	c.NoErr(err)
+
+	_, err = client.Profiles.ImportYAML(strings.NewReader("name: [unterminated"))
+	c.True(err != nil)
+
+	_, err = client.Profiles.ImportYAML(strings.NewReader("denylist: not-a-list\n"))
+	c.True(err != nil)
+}
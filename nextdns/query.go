@@ -0,0 +1,43 @@
+package nextdns
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// queryBuilder sets optional query parameters, skipping the ones with a zero value.
+type queryBuilder url.Values
+
+// newQueryBuilder returns an empty query builder.
+func newQueryBuilder() queryBuilder {
+	return queryBuilder(url.Values{})
+}
+
+// SetString sets the parameter if the value is not empty.
+func (b queryBuilder) SetString(key, value string) queryBuilder {
+	if value != "" {
+		url.Values(b).Set(key, value)
+	}
+	return b
+}
+
+// SetInt sets the parameter if the value is positive.
+func (b queryBuilder) SetInt(key string, value int) queryBuilder {
+	if value > 0 {
+		url.Values(b).Set(key, strconv.Itoa(value))
+	}
+	return b
+}
+
+// SetBool sets the parameter to "true" if the value is true.
+func (b queryBuilder) SetBool(key string, value bool) queryBuilder {
+	if value {
+		url.Values(b).Set(key, "true")
+	}
+	return b
+}
+
+// Values returns the built query parameters.
+func (b queryBuilder) Values() url.Values {
+	return url.Values(b)
+}
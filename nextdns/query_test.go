@@ -0,0 +1,45 @@
+package nextdns
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	c := is.New(t)
+
+	query := newQueryBuilder().
+		SetString("from", "-7d").
+		SetString("to", "").
+		SetInt("limit", 100).
+		SetInt("offset", 0).
+		SetBool("raw", true).
+		SetBool("root", false).
+		Values()
+
+	c.Equal(query.Encode(), "from=-7d&limit=100&raw=true")
+}
+
+func TestQueryBuilderEmpty(t *testing.T) {
+	c := is.New(t)
+
+	query := newQueryBuilder().Values()
+	c.Equal(len(query), 0)
+	c.Equal(query.Encode(), "")
+}
+
+func TestBuildTimeSeriesQuery(t *testing.T) {
+	c := is.New(t)
+
+	query := buildTimeSeriesQuery(&AnalyticsTimeSeriesOptions{
+		AnalyticsOptions: AnalyticsOptions{From: "-1d", Limit: 5},
+		Interval:         "1h",
+		Timezone:         "Europe/Paris",
+	})
+	c.Equal(query.Encode(), "from=-1d&interval=1h&limit=5&timezone=Europe%2FParis")
+
+	c.Equal(len(buildTimeSeriesQuery(nil)), 0)
+	c.Equal(len(buildAnalyticsQuery(nil)), 0)
+	c.Equal(buildLogsQuery(nil).Encode(), "sort=desc") // the logs sort defaults to desc
+}
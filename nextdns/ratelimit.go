@@ -0,0 +1,164 @@
+package nextdns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Rate limit headers returned by the NextDNS API.
+const (
+	rateLimitLimitHeader     = "X-RateLimit-Limit"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+	retryAfterHeader         = "Retry-After"
+)
+
+// autoThrottleThreshold is the number of remaining requests at or below which the auto-throttling
+// delays the next request until the rate limit resets.
+const autoThrottleThreshold = 1
+
+// RateLimit represents the rate limit policy reported by the last response of the NextDNS API.
+type RateLimit struct {
+	Limit     int       // Maximum number of requests of the current window.
+	Remaining int       // Remaining number of requests of the current window.
+	Reset     time.Time // Time at which the current window resets.
+}
+
+// rateLimiter keeps track of the rate limit reported by the API, and optionally throttles the
+// requests when the remaining requests are low. It's shared by all the services of a client.
+type rateLimiter struct {
+	mu       sync.Mutex
+	current  *RateLimit
+	throttle bool
+
+	// now and sleep are replaced in tests with a fake clock.
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// newRateLimiter returns a new rate limiter using the system clock.
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		now:   time.Now,
+		sleep: sleepContext,
+	}
+}
+
+// WithAutoThrottle delays the requests when the rate limit reported by the API is almost
+// reached, until the rate limit resets. It prevents "too many requests" errors in bulk jobs.
+func WithAutoThrottle() ClientOption {
+	return func(c *Client) error {
+		c.rateLimiter.throttle = true
+		return nil
+	}
+}
+
+// WithRateLimit throttles the requests sent by the client to rps requests per second, allowing
+// bursts of up to burst requests. Every request waits for the limiter, or until its context is
+// canceled. It prevents hammering the API in bulk jobs, like imports.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(c *Client) error {
+		if rps <= 0 {
+			return fmt.Errorf("invalid rate limit %v: must be positive", rps)
+		}
+		if burst < 1 {
+			return fmt.Errorf("invalid rate limit burst %d: must be at least 1", burst)
+		}
+
+		c.limiter = rate.NewLimiter(rate.Limit(rps), burst)
+		return nil
+	}
+}
+
+// RateLimit returns the rate limit reported by the last response of the NextDNS API, or nil if
+// no response reported it yet.
+func (c *Client) RateLimit() *RateLimit {
+	c.rateLimiter.mu.Lock()
+	defer c.rateLimiter.mu.Unlock()
+
+	if c.rateLimiter.current == nil {
+		return nil
+	}
+	rateLimit := *c.rateLimiter.current
+	return &rateLimit
+}
+
+// update records the rate limit reported by the headers of a response, if any.
+func (l *rateLimiter) update(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get(rateLimitRemainingHeader))
+	if err != nil {
+		return
+	}
+
+	rateLimit := &RateLimit{Remaining: remaining}
+	if limit, err := strconv.Atoi(header.Get(rateLimitLimitHeader)); err == nil {
+		rateLimit.Limit = limit
+	}
+	if reset, err := strconv.ParseInt(header.Get(rateLimitResetHeader), 10, 64); err == nil {
+		rateLimit.Reset = time.Unix(reset, 0)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.current = rateLimit
+}
+
+// wait delays the request until the rate limit resets, when the auto-throttling is enabled and
+// the remaining requests are low.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	if !l.throttle || l.current == nil || l.current.Remaining > autoThrottleThreshold {
+		l.mu.Unlock()
+		return nil
+	}
+
+	delay := l.current.Reset.Sub(l.now())
+	// The window is assumed reset once waited, until the next response reports the rate limit.
+	l.current = nil
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	return l.sleep(ctx, delay)
+}
+
+// parseRetryAfter parses the value of a Retry-After header, either a number of seconds or an
+// HTTP date, into the delay to wait from now. A date in the past is a delay of zero.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(date.Sub(now), 0), true
+}
+
+// sleepContext sleeps for the duration d, or until the context is canceled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,141 @@
+package nextdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestRateLimit(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "1705314600")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": []}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+	c.Equal(client.RateLimit(), nil)
+
+	_, err = client.Profiles.List(context.Background(), &ListProfileRequest{})
+	c.NoErr(err)
+
+	c.Equal(client.RateLimit(), &RateLimit{
+		Limit:     100,
+		Remaining: 42,
+		Reset:     time.Unix(1705314600, 0),
+	})
+}
+
+func TestWithAutoThrottle(t *testing.T) {
+	c := is.New(t)
+
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	remaining := 1
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(30*time.Second).Unix(), 10))
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": []}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL), WithAutoThrottle())
+	c.NoErr(err)
+
+	var slept []time.Duration
+	client.rateLimiter.now = func() time.Time { return now }
+	client.rateLimiter.sleep = func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+
+	ctx := context.Background()
+	_, err = client.Profiles.List(ctx, &ListProfileRequest{})
+	c.NoErr(err)
+	c.Equal(len(slept), 0) // no rate limit known before the first response
+
+	remaining = 50
+	_, err = client.Profiles.List(ctx, &ListProfileRequest{})
+	c.NoErr(err)
+	c.Equal(slept, []time.Duration{30 * time.Second}) // one remaining request: wait for the reset
+
+	_, err = client.Profiles.List(ctx, &ListProfileRequest{})
+	c.NoErr(err)
+	c.Equal(len(slept), 1)
+}
+
+func TestWithRateLimit(t *testing.T) {
+	c := is.New(t)
+
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": []}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL), WithRateLimit(50, 1))
+	c.NoErr(err)
+
+	// The first request uses the burst, the next five wait 20ms each.
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		_, err = client.Profiles.List(context.Background(), &ListProfileRequest{})
+		c.NoErr(err)
+	}
+
+	c.Equal(requests, 6)
+	c.True(time.Since(start) >= 100*time.Millisecond)
+}
+
+func TestWithRateLimitContextCanceled(t *testing.T) {
+	c := is.New(t)
+
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": []}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL), WithRateLimit(0.1, 1))
+	c.NoErr(err)
+
+	_, err = client.Profiles.List(context.Background(), &ListProfileRequest{})
+	c.NoErr(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Profiles.List(ctx, &ListProfileRequest{})
+	c.True(err != nil)
+	c.Equal(requests, 1)
+}
+
+func TestWithRateLimitInvalid(t *testing.T) {
+	c := is.New(t)
+
+	_, err := New(WithRateLimit(0, 1))
+	c.True(err != nil)
+
+	_, err = New(WithRateLimit(10, 0))
+	c.True(err != nil)
+}
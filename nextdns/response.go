@@ -0,0 +1,123 @@
+package nextdns
+
+import "context"
+
+// listMeta represents the optional metadata of a list response. Some list endpoints
+// (e.g. profiles) return pagination metadata, while others (e.g. blocklists) only return
+// the data, so it's embedded in every list response to decode both shapes uniformly.
+type listMeta struct {
+	Meta struct {
+		Pagination struct {
+			Cursor string `json:"cursor"`
+		} `json:"pagination"`
+	} `json:"meta,omitempty"`
+}
+
+// ListResponse contains the entries of a list with their count and the cursor of the next page,
+// empty if there are no more pages.
+type ListResponse[T any] struct {
+	Data   []T
+	Count  int
+	Cursor string
+}
+
+// newListResponse returns a ListResponse of the entries of a list response.
+func newListResponse[T any](data []T, meta listMeta) *ListResponse[T] {
+	return &ListResponse[T]{
+		Data:   data,
+		Count:  len(data),
+		Cursor: meta.cursor(),
+	}
+}
+
+// cursor returns the cursor of the next page, empty if there are no more pages.
+func (m listMeta) cursor() string {
+	return m.Meta.Pagination.Cursor
+}
+
+// collectPages walks through all the pages returned by fetch, starting without a cursor, and
+// collects their entries. When ctx is canceled mid-pagination, the entries collected so far
+// are returned alongside ctx.Err(); on any other error no entries are returned.
+func collectPages[T any](ctx context.Context, fetch func(cursor string) ([]T, string, error)) ([]T, error) {
+	var entries []T
+	cursor := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return entries, err
+		}
+
+		page, next, err := fetch(cursor)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return entries, ctxErr
+			}
+			return nil, err
+		}
+
+		entries = append(entries, page...)
+		if next == "" {
+			return entries, nil
+		}
+		cursor = next
+	}
+}
+
+// pageIterator walks through the entries of the pages returned by fetch, starting from a cursor
+// and fetching the next page when the current one is exhausted.
+type pageIterator[T any] struct {
+	fetch func(ctx context.Context, cursor string) ([]T, string, error)
+
+	page   []T
+	index  int
+	cursor string
+	done   bool
+	err    error
+}
+
+// newPageIterator returns an iterator over the pages returned by fetch, starting from cursor.
+func newPageIterator[T any](cursor string, fetch func(ctx context.Context, cursor string) ([]T, string, error)) *pageIterator[T] {
+	return &pageIterator[T]{
+		fetch:  fetch,
+		index:  -1,
+		cursor: cursor,
+	}
+}
+
+// next advances to the next entry, fetching the next page if needed. It returns false when there
+// are no more entries or an error occurred. The iteration ends after a page without a cursor, an
+// empty page, or a page returning the cursor it was fetched with, so that a cursor which never
+// changes can't loop forever.
+func (it *pageIterator[T]) next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.index++
+	for it.index >= len(it.page) {
+		if it.done {
+			return false
+		}
+
+		page, cursor, err := it.fetch(ctx, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.done = cursor == "" || len(page) == 0 || cursor == it.cursor
+		it.page = page
+		it.index = 0
+		it.cursor = cursor
+	}
+
+	return true
+}
+
+// entry returns the current entry, or the zero value if there is none.
+func (it *pageIterator[T]) entry() T {
+	if it.index < 0 || it.index >= len(it.page) {
+		var zero T
+		return zero
+	}
+	return it.page[it.index]
+}
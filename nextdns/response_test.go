@@ -0,0 +1,57 @@
+package nextdns
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestListResponseWithoutMeta(t *testing.T) {
+	c := is.New(t)
+
+	jsonData := `{"data": [{"id": "nextdns-recommended"}, {"id": "oisd"}]}`
+
+	var resp privacyBlocklistsResponse
+	err := json.Unmarshal([]byte(jsonData), &resp)
+	c.NoErr(err)
+
+	c.Equal(len(resp.PrivacyBlocklists), 2)
+	c.Equal(resp.PrivacyBlocklists[1].ID, "oisd")
+	c.Equal(resp.cursor(), "")
+}
+
+func TestListResponseWithMeta(t *testing.T) {
+	c := is.New(t)
+
+	jsonData := `{"data": [{"id": "nextdns-recommended"}], "meta": {"pagination": {"cursor": "next"}}}`
+
+	var resp privacyBlocklistsResponse
+	err := json.Unmarshal([]byte(jsonData), &resp)
+	c.NoErr(err)
+
+	c.Equal(len(resp.PrivacyBlocklists), 1)
+	c.Equal(resp.cursor(), "next")
+
+	var profiles profilesResponse
+	err = json.Unmarshal([]byte(`{"data": [{"id": "abc123"}], "meta": {"pagination": {"cursor": "page2"}}}`), &profiles)
+	c.NoErr(err)
+
+	c.Equal(len(profiles.Profiles), 1)
+	c.Equal(profiles.cursor(), "page2")
+}
+
+func TestNewListResponse(t *testing.T) {
+	c := is.New(t)
+
+	meta := listMeta{}
+	meta.Meta.Pagination.Cursor = "next"
+
+	response := newListResponse([]string{"a", "b"}, meta)
+	c.Equal(response.Count, 2)
+	c.Equal(response.Data, []string{"a", "b"})
+	c.Equal(response.Cursor, "next")
+
+	empty := newListResponse([]string(nil), listMeta{})
+	c.Equal(empty.Count, 0)
+}
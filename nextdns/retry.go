@@ -0,0 +1,130 @@
+package nextdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// retryPolicy retries the idempotent requests failing with a transient error, waiting for an
+// exponential backoff with jitter between the attempts.
+type retryPolicy struct {
+	maxRetries int
+	baseDelay  time.Duration
+
+	// sleep is replaced in tests to skip the delays.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// WithRetry retries the GET, PUT and DELETE requests up to maxRetries times when they fail with
+// a network error or a 429, 500, 502, 503 or 504 response. The attempt n waits baseDelay * 2^n,
+// with a random jitter of up to half of it, or the delay of the Retry-After header of a 429
+// response, or until the context is canceled. The other requests and the other errors, like the
+// other 4xx responses, are never retried.
+func WithRetry(maxRetries int, baseDelay time.Duration) ClientOption {
+	return func(c *Client) error {
+		if maxRetries < 0 {
+			return fmt.Errorf("invalid max retries %d: must not be negative", maxRetries)
+		}
+		if baseDelay < 0 {
+			return fmt.Errorf("invalid retry base delay %s: must not be negative", baseDelay)
+		}
+
+		c.retry = &retryPolicy{
+			maxRetries: maxRetries,
+			baseDelay:  baseDelay,
+			sleep:      sleepContext,
+		}
+		return nil
+	}
+}
+
+// shouldRetry reports whether a request should be sent again after the given attempt, counted
+// from zero, returned the response res or the error err.
+func (p *retryPolicy) shouldRetry(ctx context.Context, req *http.Request, res *http.Response, err error, attempt int) bool {
+	if p == nil || attempt >= p.maxRetries || ctx.Err() != nil {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+
+	// A request with a body can only be sent again if the body can be rewound.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff waits before the attempt following the given one, or until the context is canceled.
+// A rate limit response waits for the delay of its Retry-After header, when set.
+func (p *retryPolicy) backoff(ctx context.Context, attempt int, res *http.Response) error {
+	if res != nil && res.StatusCode == http.StatusTooManyRequests {
+		if delay, ok := parseRetryAfter(res.Header.Get(retryAfterHeader), time.Now()); ok {
+			return p.sleep(ctx, delay)
+		}
+	}
+
+	delay := p.baseDelay << attempt
+	if delay > 0 {
+		delay += rand.N(delay/2 + 1)
+	}
+	return p.sleep(ctx, delay)
+}
+
+// send sends a request, sending it again on the transient failures retried by WithRetry.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		if err := c.rateLimiter.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		res, err := c.client.Do(req)
+		if err == nil {
+			c.rateLimiter.update(res.Header)
+		}
+
+		if !c.retry.shouldRetry(ctx, req, res, err, attempt) {
+			return res, err
+		}
+
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+
+		if err := c.retry.backoff(ctx, attempt, res); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package nextdns
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestWithRetry(t *testing.T) {
+	c := is.New(t)
+
+	var attempts int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PUT")
+		body, err := io.ReadAll(r.Body)
+		c.NoErr(err)
+		c.Equal(string(body), "[{\"id\":\"ru\"}]\n")
+		attempts++
+
+		// The first two attempts fail with a transient error.
+		if attempts <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL), WithRetry(3, time.Millisecond))
+	c.NoErr(err)
+
+	err = client.SecurityTlds.Create(context.Background(), &CreateSecurityTldsRequest{
+		ProfileID:    "abc123",
+		SecurityTlds: []*SecurityTlds{{ID: "ru"}},
+	})
+	c.NoErr(err)
+	c.Equal(attempts, 3)
+}
+
+func TestWithRetryNetworkError(t *testing.T) {
+	c := is.New(t)
+
+	var attempts int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+
+		// The first two attempts have their connection reset.
+		if attempts <= 2 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			c.NoErr(err)
+			c.NoErr(conn.Close())
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": []}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL), WithRetry(2, time.Millisecond))
+	c.NoErr(err)
+
+	_, err = client.Profiles.List(context.Background(), &ListProfileRequest{})
+	c.NoErr(err)
+	c.Equal(attempts, 3)
+}
+
+func TestWithRetryExhausted(t *testing.T) {
+	c := is.New(t)
+
+	var attempts int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL), WithRetry(2, time.Millisecond))
+	c.NoErr(err)
+
+	_, err = client.Profiles.List(context.Background(), &ListProfileRequest{})
+	var e *Error
+	c.True(errors.As(err, &e))
+	c.Equal(e.Type, ErrorTypeServiceError)
+	c.Equal(attempts, 3)
+}
+
+func TestWithRetryNotRetried(t *testing.T) {
+	c := is.New(t)
+
+	var attempts int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		// POST requests aren't idempotent, and 4xx responses aren't transient.
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(`{"errors": [{"code": "notFound"}]}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL), WithRetry(3, time.Millisecond))
+	c.NoErr(err)
+
+	_, err = client.Profiles.Create(context.Background(), &CreateProfileRequest{Name: "test"})
+	c.True(err != nil)
+	c.Equal(attempts, 1)
+
+	attempts = 0
+	_, err = client.Profiles.Get(context.Background(), &GetProfileRequest{ProfileID: "abc123"})
+	c.True(err != nil)
+	c.Equal(attempts, 1)
+}
+
+func TestWithRetryContextCanceled(t *testing.T) {
+	c := is.New(t)
+
+	var attempts int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL), WithRetry(3, time.Hour))
+	c.NoErr(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Profiles.List(ctx, &ListProfileRequest{})
+	c.True(errors.Is(err, context.DeadlineExceeded))
+	c.Equal(attempts, 1)
+}
+
+func TestWithRetryInvalid(t *testing.T) {
+	c := is.New(t)
+
+	_, err := New(WithRetry(-1, time.Second))
+	c.True(err != nil)
+
+	_, err = New(WithRetry(3, -time.Second))
+	c.True(err != nil)
+}
+
+func TestWithRetryRetryAfter(t *testing.T) {
+	c := is.New(t)
+
+	var attempts int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": []}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL), WithRetry(1, time.Millisecond))
+	c.NoErr(err)
+
+	var delays []time.Duration
+	client.retry.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	_, err = client.Profiles.List(context.Background(), &ListProfileRequest{})
+	c.NoErr(err)
+	c.Equal(attempts, 2)
+	c.Equal(delays, []time.Duration{7 * time.Second})
+}
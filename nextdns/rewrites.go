@@ -2,6 +2,7 @@ package nextdns
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -9,6 +10,14 @@ import (
 // rewritesAPIPath is the HTTP path for the rewrites API.
 const rewritesAPIPath = "rewrites"
 
+// Rewrite record types supported by the API. A rewrite without a type has it inferred from
+// its content.
+const (
+	RewriteTypeA     = "A"
+	RewriteTypeAAAA  = "AAAA"
+	RewriteTypeCNAME = "CNAME"
+)
+
 // Rewrites represents the rewrite list of a profile.
 type Rewrites struct {
 	ID      string `json:"id,omitempty"`
@@ -28,6 +37,25 @@ type ListRewritesRequest struct {
 	ProfileID string
 }
 
+// ReplaceRewritesRequest encapsulates the request for replacing all the rewrites of a profile.
+type ReplaceRewritesRequest struct {
+	ProfileID string
+	Rewrites  []*Rewrites
+}
+
+// GetRewritesRequest encapsulates the request for getting a single rewrite.
+type GetRewritesRequest struct {
+	ProfileID string
+	ID        string
+}
+
+// UpdateRewritesRequest encapsulates the request for updating a rewrite.
+type UpdateRewritesRequest struct {
+	ProfileID string
+	ID        string
+	Rewrites  *Rewrites
+}
+
 // DeleteRewritesRequest encapsulates the request for deleting a rewrite.
 type DeleteRewritesRequest struct {
 	ProfileID string
@@ -37,13 +65,17 @@ type DeleteRewritesRequest struct {
 // RewritesService is an interface for communicating with the NextDNS rewrites API endpoint.
 type RewritesService interface {
 	Create(context.Context, *CreateRewritesRequest) (string, error)
+	Replace(context.Context, *ReplaceRewritesRequest) error
 	List(context.Context, *ListRewritesRequest) ([]*Rewrites, error)
+	Get(context.Context, *GetRewritesRequest) (*Rewrites, error)
+	Update(context.Context, *UpdateRewritesRequest) error
 	Delete(context.Context, *DeleteRewritesRequest) error
 }
 
 // rewritesResponse represents the rewrites response.
 type rewritesResponse struct {
 	Rewrites []*Rewrites `json:"data"`
+	listMeta
 }
 
 // createRewritesResponse represents the response when creating a rewrite from the NextDNS API.
@@ -51,9 +83,14 @@ type createRewritesResponse struct {
 	Rewrites *Rewrites `json:"data"`
 }
 
+// rewriteResponse represents the response for a single rewrite from the NextDNS API.
+type rewriteResponse struct {
+	Rewrites *Rewrites `json:"data"`
+}
+
 // privacyService represents the NextDNS rewrites service.
 type rewritesService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ RewritesService = &rewritesService{}
@@ -62,12 +99,17 @@ var _ RewritesService = &rewritesService{}
 // nolint: revive
 func NewRewritesService(client *Client) *rewritesService {
 	return &rewritesService{
-		client: client,
+		client: newServiceClient(client, ServiceRewrites),
 	}
 }
 
-// Create creates a rewrite and returns its ID.
+// Create creates a rewrite and returns its ID. The type and content of the rewrite are checked
+// before making the request.
 func (s *rewritesService) Create(ctx context.Context, request *CreateRewritesRequest) (string, error) {
+	if err := newRewritesValidationError(validateRewrite("rewrite", request.Rewrites)); err != nil {
+		return "", fmt.Errorf("error validating request to create a rewrite: %w", err)
+	}
+
 	path := fmt.Sprintf("%s/%s", profileAPIPath(request.ProfileID), rewritesAPIPath)
 
 	req, err := s.client.newRequest(http.MethodPost, path, request.Rewrites)
@@ -84,6 +126,35 @@ func (s *rewritesService) Create(ctx context.Context, request *CreateRewritesReq
 	return response.Rewrites.ID, nil
 }
 
+// Replace replaces all the rewrites of a profile in a single request. The type and content of
+// every rewrite are checked before making the request.
+func (s *rewritesService) Replace(ctx context.Context, request *ReplaceRewritesRequest) error {
+	var errs []error
+	for i, rewrite := range request.Rewrites {
+		errs = append(errs, validateRewrite(fmt.Sprintf("rewrites[%d]", i), rewrite)...)
+	}
+	if err := newRewritesValidationError(errs); err != nil {
+		return fmt.Errorf("error validating request to replace the rewrite list: %w", err)
+	}
+
+	path := fmt.Sprintf("%s/%s", profileAPIPath(request.ProfileID), rewritesAPIPath)
+	req, err := s.client.newRequest(http.MethodPut, path, request.Rewrites)
+	if err != nil {
+		return fmt.Errorf("error creating request to replace the rewrite list: %w", err)
+	}
+
+	err = s.client.do(ctx, req, nil)
+	if err != nil {
+		ids := make([]string, len(request.Rewrites))
+		for i, entry := range request.Rewrites {
+			ids[i] = entry.Name
+		}
+		return fmt.Errorf("error making a request to replace the rewrite list: %w", newBulkError(err, ids))
+	}
+
+	return nil
+}
+
 // List returns the rewrites of a profile.
 func (s *rewritesService) List(ctx context.Context, request *ListRewritesRequest) ([]*Rewrites, error) {
 	path := fmt.Sprintf("%s/%s", profileAPIPath(request.ProfileID), rewritesAPIPath)
@@ -101,6 +172,57 @@ func (s *rewritesService) List(ctx context.Context, request *ListRewritesRequest
 	return response.Rewrites, nil
 }
 
+// Get returns a single rewrite of a profile.
+func (s *rewritesService) Get(ctx context.Context, request *GetRewritesRequest) (*Rewrites, error) {
+	path := fmt.Sprintf("%s/%s", profileAPIPath(request.ProfileID), rewritesIDAPIPath(request.ID))
+	req, err := s.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to get the rewrite %s: %w", request.ID, err)
+	}
+
+	response := rewriteResponse{}
+	err = s.client.do(ctx, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error making a request to get the rewrite %s: %w", request.ID, err)
+	}
+
+	return response.Rewrites, nil
+}
+
+// Update updates a rewrite. Only the non-empty fields of the rewrite are changed. The type and
+// content, when set, are checked before making the request.
+func (s *rewritesService) Update(ctx context.Context, request *UpdateRewritesRequest) error {
+	if request.Rewrites == nil {
+		err := newRewritesValidationError([]error{&ValidationError{Field: "rewrite", Message: "must not be nil"}})
+		return fmt.Errorf("error validating request to update the rewrite %s: %w", request.ID, err)
+	}
+	if err := newRewritesValidationError(validateRewriteContent("rewrite", request.Rewrites.Type, request.Rewrites.Content)); err != nil {
+		return fmt.Errorf("error validating request to update the rewrite %s: %w", request.ID, err)
+	}
+
+	path := fmt.Sprintf("%s/%s", profileAPIPath(request.ProfileID), rewritesIDAPIPath(request.ID))
+	body := struct {
+		Name    string `json:"name,omitempty"`
+		Type    string `json:"type,omitempty"`
+		Content string `json:"content,omitempty"`
+	}{
+		Name:    request.Rewrites.Name,
+		Type:    request.Rewrites.Type,
+		Content: request.Rewrites.Content,
+	}
+	req, err := s.client.newRequest(http.MethodPatch, path, body)
+	if err != nil {
+		return fmt.Errorf("error creating request to update the rewrite %s: %w", request.ID, err)
+	}
+
+	err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return fmt.Errorf("error making a request to update the rewrite %s: %w", request.ID, err)
+	}
+
+	return nil
+}
+
 // Delete deletes a profile.
 func (s *rewritesService) Delete(ctx context.Context, request *DeleteRewritesRequest) error {
 	path := fmt.Sprintf("%s/%s", profileAPIPath(request.ProfileID), rewritesIDAPIPath(request.ID))
@@ -119,5 +241,18 @@ func (s *rewritesService) Delete(ctx context.Context, request *DeleteRewritesReq
 
 // rewritesIDAPIPath returns the HTTP path for the rewrites API.
 func rewritesIDAPIPath(id string) string {
-	return fmt.Sprintf("%s/%s", rewritesAPIPath, id)
+	return fmt.Sprintf("%s/%s", rewritesAPIPath, pathSegment(id))
+}
+
+// newRewritesValidationError returns an *Error wrapping the violations found by the validation of
+// the rewrites, each a *ValidationError, or nil if there are none.
+func newRewritesValidationError(errs []error) error {
+	if err := errors.Join(errs...); err != nil {
+		return &Error{
+			Type:    ErrorTypeRequest,
+			Message: "invalid rewrites",
+			Err:     err,
+		}
+	}
+	return nil
 }
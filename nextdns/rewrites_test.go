@@ -0,0 +1,247 @@
+package nextdns
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestRewritesUpdate(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PATCH")
+		c.Equal(r.URL.Path, "/profiles/abc123/rewrites/2b4a7e")
+
+		body, err := io.ReadAll(r.Body)
+		c.NoErr(err)
+		c.Equal(string(body), `{"content":"192.168.1.20"}`+"\n")
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	err = client.Rewrites.Update(ctx, &UpdateRewritesRequest{
+		ProfileID: "abc123",
+		ID:        "2b4a7e",
+		Rewrites:  &Rewrites{Content: "192.168.1.20"},
+	})
+
+	c.NoErr(err)
+}
+
+func TestRewritesGet(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/rewrites/2b4a7e")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": {"id": "2b4a7e", "name": "nas.home", "type": "A", "content": "192.168.1.10"}}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	rewrite, err := client.Rewrites.Get(context.Background(), &GetRewritesRequest{
+		ProfileID: "abc123",
+		ID:        "2b4a7e",
+	})
+
+	c.NoErr(err)
+	c.Equal(rewrite, &Rewrites{ID: "2b4a7e", Name: "nas.home", Type: "A", Content: "192.168.1.10"})
+}
+
+func TestRewritesGetNotFound(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(`{"errors": [{"code": "notFound"}]}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	rewrite, err := client.Rewrites.Get(context.Background(), &GetRewritesRequest{
+		ProfileID: "abc123",
+		ID:        "missing",
+	})
+
+	c.Equal(rewrite, nil)
+	var e *Error
+	c.True(errors.As(err, &e))
+	c.Equal(e.Type, ErrorTypeNotFound)
+}
+
+func TestRewritesReplace(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PUT")
+		c.Equal(r.URL.Path, "/profiles/abc123/rewrites")
+
+		body, err := io.ReadAll(r.Body)
+		c.NoErr(err)
+		c.Equal(string(body), `[{"name":"nas.home","type":"A","content":"192.168.1.10"},{"name":"printer.home","content":"192.168.1.11"}]`+"\n")
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	err = client.Rewrites.Replace(context.Background(), &ReplaceRewritesRequest{
+		ProfileID: "abc123",
+		Rewrites: []*Rewrites{
+			{Name: "nas.home", Type: "A", Content: "192.168.1.10"},
+			{Name: "printer.home", Content: "192.168.1.11"},
+		},
+	})
+
+	c.NoErr(err)
+}
+
+func TestRewritesCreateValidate(t *testing.T) {
+	c := is.New(t)
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "POST")
+		c.Equal(r.URL.Path, "/profiles/abc123/rewrites")
+		calls++
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": {"id": "2b4a7e"}}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	tests := []struct {
+		name    string
+		rewrite *Rewrites
+		field   string
+	}{
+		{name: "inferred type", rewrite: &Rewrites{Name: "nas.home", Content: "192.168.1.10"}},
+		{name: "A", rewrite: &Rewrites{Name: "nas.home", Type: "A", Content: "192.168.1.10"}},
+		{name: "AAAA", rewrite: &Rewrites{Name: "nas.home", Type: "AAAA", Content: "fd00::10"}},
+		{name: "CNAME", rewrite: &Rewrites{Name: "www.home", Type: "CNAME", Content: "nas.home."}},
+		{name: "wildcard name", rewrite: &Rewrites{Name: "*.internal.example.com", Type: "A", Content: "10.0.0.1"}},
+		{name: "type typo", rewrite: &Rewrites{Name: "www.home", Type: "CNAMe", Content: "nas.home"}, field: "rewrite.type"},
+		{name: "unsupported type", rewrite: &Rewrites{Name: "mail.home", Type: "MX", Content: "nas.home"}, field: "rewrite.type"},
+		{name: "A with IPv6", rewrite: &Rewrites{Name: "nas.home", Type: "A", Content: "fd00::10"}, field: "rewrite.content"},
+		{name: "A with hostname", rewrite: &Rewrites{Name: "nas.home", Type: "A", Content: "nas.lan"}, field: "rewrite.content"},
+		{name: "AAAA with IPv4", rewrite: &Rewrites{Name: "nas.home", Type: "AAAA", Content: "192.168.1.10"}, field: "rewrite.content"},
+		{name: "CNAME with wildcard", rewrite: &Rewrites{Name: "www.home", Type: "CNAME", Content: "*.nas.home"}, field: "rewrite.content"},
+		{name: "CNAME with spaces", rewrite: &Rewrites{Name: "www.home", Type: "CNAME", Content: "nas home"}, field: "rewrite.content"},
+		{name: "empty content", rewrite: &Rewrites{Name: "nas.home", Type: "A"}, field: "rewrite.content"},
+	}
+
+	for _, tt := range tests {
+		calls = 0
+		_, err := client.Rewrites.Create(context.Background(), &CreateRewritesRequest{
+			ProfileID: "abc123",
+			Rewrites:  tt.rewrite,
+		})
+
+		if tt.field == "" {
+			c.NoErr(err) // valid rewrite
+			c.Equal(calls, 1)
+			continue
+		}
+
+		var e *Error
+		c.True(errors.As(err, &e))
+		c.Equal(e.Type, ErrorTypeRequest)
+		var v *ValidationError
+		c.True(errors.As(err, &v))
+		c.Equal(v.Field, tt.field)
+		c.Equal(calls, 0)
+	}
+}
+
+func TestRewritesReplaceValidate(t *testing.T) {
+	c := is.New(t)
+
+	client, err := New(WithBaseURL("http://localhost"))
+	c.NoErr(err)
+
+	err = client.Rewrites.Replace(context.Background(), &ReplaceRewritesRequest{
+		ProfileID: "abc123",
+		Rewrites: []*Rewrites{
+			{Name: "nas.home", Type: "A", Content: "192.168.1.10"},
+			{Name: "printer.home", Type: "AAAA", Content: "192.168.1.11"},
+		},
+	})
+
+	var v *ValidationError
+	c.True(errors.As(err, &v))
+	c.Equal(v.Field, "rewrites[1].content")
+}
+
+func TestRewritesUpdateValidate(t *testing.T) {
+	c := is.New(t)
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	tests := []struct {
+		rewrite *Rewrites
+		field   string
+	}{
+		{rewrite: &Rewrites{Name: "nas.home"}},
+		{rewrite: &Rewrites{Content: "192.168.1.20"}},
+		{rewrite: &Rewrites{Type: "AAAA", Content: "fd00::20"}},
+		{rewrite: nil, field: "rewrite"},
+		{rewrite: &Rewrites{Type: "CNAMe"}, field: "rewrite.type"},
+		{rewrite: &Rewrites{Type: "A", Content: "fd00::20"}, field: "rewrite.content"},
+	}
+
+	for _, tt := range tests {
+		calls = 0
+		err := client.Rewrites.Update(context.Background(), &UpdateRewritesRequest{
+			ProfileID: "abc123",
+			ID:        "2b4a7e",
+			Rewrites:  tt.rewrite,
+		})
+
+		if tt.field == "" {
+			c.NoErr(err) // valid update
+			c.Equal(calls, 1)
+			continue
+		}
+
+		var e *Error
+		c.True(errors.As(err, &e))
+		c.Equal(e.Type, ErrorTypeRequest)
+		var v *ValidationError
+		c.True(errors.As(err, &v))
+		c.Equal(v.Field, tt.field)
+		c.Equal(calls, 0)
+	}
+}
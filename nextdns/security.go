@@ -50,7 +50,7 @@ type securityResponse struct {
 
 // securityService represents the NextDNS security service.
 type securityService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ SecurityService = &securityService{}
@@ -59,7 +59,7 @@ var _ SecurityService = &securityService{}
 // nolint: revive
 func NewSecurityService(client *Client) *securityService {
 	return &securityService{
-		client: client,
+		client: newServiceClient(client, ServiceSecurity),
 	}
 }
 
@@ -88,8 +88,7 @@ func (s *securityService) Update(ctx context.Context, request *UpdateSecurityReq
 		return fmt.Errorf("error creating request to update the security settings: %w", err)
 	}
 
-	response := securityResponse{}
-	err = s.client.do(ctx, req, &response)
+	err = s.client.do(ctx, req, nil)
 	if err != nil {
 		return fmt.Errorf("error making a request to update the security settings: %w", err)
 	}
@@ -2,6 +2,7 @@ package nextdns
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -53,19 +54,23 @@ type DeleteSecurityTldsRequest struct {
 type SecurityTldsService interface {
 	Create(context.Context, *CreateSecurityTldsRequest) error
 	List(context.Context, *ListSecurityTldsRequest) ([]*SecurityTlds, error)
+	ListWithMeta(context.Context, *ListSecurityTldsRequest) (*ListResponse[*SecurityTlds], error)
 	Add(context.Context, *AddSecurityTldsRequest) error
 	Update(context.Context, *UpdateSecurityTldsRequest) error
 	Delete(context.Context, *DeleteSecurityTldsRequest) error
+	Enable(ctx context.Context, profileID string, tld string) error
+	Disable(ctx context.Context, profileID string, tld string) error
 }
 
 // securityTldsResponse represents the security TLDs response.
 type securityTldsResponse struct {
 	SecurityTlds []*SecurityTlds `json:"data"`
+	listMeta
 }
 
 // securityTldsService represents the NextDNS security TLDs service.
 type securityTldsService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ SecurityTldsService = &securityTldsService{}
@@ -74,20 +79,24 @@ var _ SecurityTldsService = &securityTldsService{}
 // nolint: revive
 func NewSecurityTldsService(client *Client) *securityTldsService {
 	return &securityTldsService{
-		client: client,
+		client: newServiceClient(client, ServiceSecurityTlds),
 	}
 }
 
-// Create creates a security TLDs list.
+// Create creates a security TLDs list, replacing the current one. The whole list is validated
+// before sending it, and the error lists every invalid TLD, each as a *ValidationError.
 func (s *securityTldsService) Create(ctx context.Context, request *CreateSecurityTldsRequest) error {
+	if err := errors.Join(validateSecurityTlds(request.SecurityTlds)...); err != nil {
+		return fmt.Errorf("error validating request to create a security tlds list: %w", err)
+	}
+
 	path := fmt.Sprintf("%s/%s", profileAPIPath(request.ProfileID), securityTldsAPIPath)
 	req, err := s.client.newRequest(http.MethodPut, path, request.SecurityTlds)
 	if err != nil {
 		return fmt.Errorf("error creating request to create a security tlds list: %w", err)
 	}
 
-	response := securityTldsResponse{}
-	err = s.client.do(ctx, req, &response)
+	err = s.client.do(ctx, req, nil)
 	if err != nil {
 		return fmt.Errorf("error making a request to create a security tlds list: %w", err)
 	}
@@ -97,6 +106,16 @@ func (s *securityTldsService) Create(ctx context.Context, request *CreateSecurit
 
 // List returns a security TLDs list.
 func (s *securityTldsService) List(ctx context.Context, request *ListSecurityTldsRequest) ([]*SecurityTlds, error) {
+	response, err := s.ListWithMeta(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// ListWithMeta returns the security TLDs list along with its count.
+func (s *securityTldsService) ListWithMeta(ctx context.Context, request *ListSecurityTldsRequest) (*ListResponse[*SecurityTlds], error) {
 	path := fmt.Sprintf("%s/%s", profileAPIPath(request.ProfileID), securityTldsAPIPath)
 	req, err := s.client.newRequest(http.MethodGet, path, nil)
 	if err != nil {
@@ -109,7 +128,7 @@ func (s *securityTldsService) List(ctx context.Context, request *ListSecurityTld
 		return nil, fmt.Errorf("error making a request to list the security tlds list: %w", err)
 	}
 
-	return response.SecurityTlds, nil
+	return newListResponse(response.SecurityTlds, response.listMeta), nil
 }
 
 // Add adds a single TLD to the blocked list.
@@ -169,3 +188,23 @@ func (s *securityTldsService) Delete(ctx context.Context, request *DeleteSecurit
 
 	return nil
 }
+
+// Enable activates a single TLD entry, without changing anything else.
+func (s *securityTldsService) Enable(ctx context.Context, profileID string, tld string) error {
+	active := true
+	return s.Update(ctx, &UpdateSecurityTldsRequest{
+		ProfileID: profileID,
+		TldID:     tld,
+		Active:    &active,
+	})
+}
+
+// Disable deactivates a single TLD entry without deleting it from the blocked list.
+func (s *securityTldsService) Disable(ctx context.Context, profileID string, tld string) error {
+	active := false
+	return s.Update(ctx, &UpdateSecurityTldsRequest{
+		ProfileID: profileID,
+		TldID:     tld,
+		Active:    &active,
+	})
+}
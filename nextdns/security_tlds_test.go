@@ -2,6 +2,8 @@ package nextdns
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -88,3 +90,111 @@ func TestSecurityTldsDelete(t *testing.T) {
 
 	c.NoErr(err)
 }
+
+func TestSecurityTldsEnable(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PATCH")
+		c.Equal(r.URL.Path, "/profiles/abc123/security/tlds/xyz")
+
+		body, err := io.ReadAll(r.Body)
+		c.NoErr(err)
+		c.Equal(string(body), "{\"active\":true}\n")
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	err = client.SecurityTlds.Enable(context.Background(), "abc123", "xyz")
+	c.NoErr(err)
+}
+
+func TestSecurityTldsDisable(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PATCH")
+		c.Equal(r.URL.Path, "/profiles/abc123/security/tlds/xyz")
+
+		body, err := io.ReadAll(r.Body)
+		c.NoErr(err)
+		c.Equal(string(body), "{\"active\":false}\n")
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	err = client.SecurityTlds.Disable(context.Background(), "abc123", "xyz")
+	c.NoErr(err)
+}
+
+func TestSecurityTldsListWithMeta(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/security/tlds")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": [{"id": "ru"}, {"id": "cn"}, {"id": "tk"}, {"id": "xyz"}]}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	response, err := client.SecurityTlds.ListWithMeta(ctx, &ListSecurityTldsRequest{ProfileID: "abc123"})
+
+	c.NoErr(err)
+	c.Equal(response.Count, 4)
+	c.Equal(len(response.Data), 4)
+	c.Equal(response.Cursor, "")
+}
+
+func TestSecurityTldsCreateValidation(t *testing.T) {
+	c := is.New(t)
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	err = client.SecurityTlds.Create(ctx, &CreateSecurityTldsRequest{
+		ProfileID:    "abc123",
+		SecurityTlds: []*SecurityTlds{{ID: "ru"}, {ID: ".zip"}, {ID: "xn--p1ai"}, {ID: "MOV"}, {ID: "c_m"}, nil},
+	})
+
+	var joined interface{ Unwrap() []error }
+	c.True(errors.As(err, &joined))
+
+	var fields []string
+	for _, e := range joined.Unwrap() {
+		var validationErr *ValidationError
+		c.True(errors.As(e, &validationErr))
+		fields = append(fields, validationErr.Field)
+	}
+	c.Equal(fields, []string{"securityTlds[1].id", "securityTlds[3].id", "securityTlds[4].id", "securityTlds[5]"})
+	c.Equal(calls, 0)
+
+	err = client.SecurityTlds.Create(ctx, &CreateSecurityTldsRequest{
+		ProfileID:    "abc123",
+		SecurityTlds: []*SecurityTlds{{ID: "ru"}, {ID: "xn--p1ai"}},
+	})
+	c.NoErr(err)
+	c.Equal(calls, 1)
+}
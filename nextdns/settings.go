@@ -33,6 +33,7 @@ type GetSettingsRequest struct {
 type SettingsService interface {
 	Get(context.Context, *GetSettingsRequest) (*Settings, error)
 	Update(context.Context, *UpdateSettingsRequest) error
+	SetWeb3(ctx context.Context, profileID string, on bool) error
 }
 
 // settingsResponse represents the settings response.
@@ -42,7 +43,7 @@ type settingsResponse struct {
 
 // settingsService represents the NextDNS settings service.
 type settingsService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ SettingsService = &settingsService{}
@@ -51,7 +52,7 @@ var _ SettingsService = &settingsService{}
 // nolint: revive
 func NewSettingsService(client *Client) *settingsService {
 	return &settingsService{
-		client: client,
+		client: newServiceClient(client, ServiceSettings),
 	}
 }
 
@@ -80,11 +81,32 @@ func (s *settingsService) Update(ctx context.Context, request *UpdateSettingsReq
 		return fmt.Errorf("error creating request to update the settings: %w", err)
 	}
 
-	response := settingsResponse{}
-	err = s.client.do(ctx, req, &response)
+	err = s.client.do(ctx, req, nil)
 	if err != nil {
 		return fmt.Errorf("error making a request to update the settings: %w", err)
 	}
 
 	return nil
 }
+
+// SetWeb3 turns the Web3 domains resolution of a profile on or off. Only the web3 field is sent,
+// so the other settings are left unchanged.
+func (s *settingsService) SetWeb3(ctx context.Context, profileID string, on bool) error {
+	path := fmt.Sprintf("%s/%s", profileAPIPath(profileID), settingsAPIPath)
+	body := struct {
+		Web3 *bool `json:"web3"`
+	}{
+		Web3: &on,
+	}
+	req, err := s.client.newRequest(http.MethodPatch, path, body)
+	if err != nil {
+		return fmt.Errorf("error creating request to set the settings web3: %w", err)
+	}
+
+	err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return fmt.Errorf("error making a request to set the settings web3: %w", err)
+	}
+
+	return nil
+}
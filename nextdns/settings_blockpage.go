@@ -38,7 +38,7 @@ type settingsBlockPageResponse struct {
 
 // settingsBlockPageService represents the NextDNS settings block page service.
 type settingsBlockPageService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ SettingsBlockPageService = &settingsBlockPageService{}
@@ -47,7 +47,7 @@ var _ SettingsBlockPageService = &settingsBlockPageService{}
 // nolint: revive
 func NewSettingsBlockPageService(client *Client) *settingsBlockPageService {
 	return &settingsBlockPageService{
-		client: client,
+		client: newServiceClient(client, ServiceSettingsBlockPage),
 	}
 }
 
@@ -76,8 +76,7 @@ func (s *settingsBlockPageService) Update(ctx context.Context, request *UpdateSe
 		return fmt.Errorf("error creating request to update the block page settings: %w", err)
 	}
 
-	response := settingsBlockPageResponse{}
-	err = s.client.do(ctx, req, &response)
+	err = s.client.do(ctx, req, nil)
 	if err != nil {
 		return fmt.Errorf("error making a request to update the block page settings: %w", err)
 	}
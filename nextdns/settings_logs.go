@@ -2,19 +2,86 @@ package nextdns
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 )
 
 // settingsLogsAPIPath is the HTTP path for the settings logs API.
 const settingsLogsAPIPath = "settings/logs"
 
+// Logs retention periods supported by the NextDNS API, in seconds (see SettingsLogs.Retention).
+const (
+	LogsRetention1Hour   = 3600
+	LogsRetention6Hours  = 21600
+	LogsRetention1Day    = 86400
+	LogsRetention7Days   = 604800
+	LogsRetention30Days  = 2592000
+	LogsRetention90Days  = 7776000
+	LogsRetention180Days = 15552000
+	LogsRetention1Year   = 31536000
+	LogsRetention2Years  = 63072000
+)
+
 // SettingsLogsDrop represents the settings logs privacy adjustments of a profile.
+// The NextDNS API documents the "ip" and "domain" toggles; any other drop toggle returned by
+// the API is kept in Extra, so it survives a read-modify-write round trip.
 type SettingsLogsDrop struct {
+	IP     bool            `json:"ip"`
+	Domain bool            `json:"domain"`
+	Extra  map[string]bool `json:"-"`
+}
+
+// settingsLogsDrop is used to marshal and unmarshal the known fields of SettingsLogsDrop.
+type settingsLogsDrop struct {
 	IP     bool `json:"ip"`
 	Domain bool `json:"domain"`
 }
 
+// MarshalJSON marshals the drop toggles, including the extra ones.
+func (d SettingsLogsDrop) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]bool, len(d.Extra)+2)
+	for key, value := range d.Extra {
+		fields[key] = value
+	}
+	fields["ip"] = d.IP
+	fields["domain"] = d.Domain
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON unmarshals the drop toggles, keeping the unknown ones in Extra.
+func (d *SettingsLogsDrop) UnmarshalJSON(data []byte) error {
+	known := settingsLogsDrop{}
+	if err := json.Unmarshal(data, &known); err != nil {
+		return err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	d.IP = known.IP
+	d.Domain = known.Domain
+	d.Extra = nil
+	for key, raw := range fields {
+		if key == "ip" || key == "domain" {
+			continue
+		}
+		var value bool
+		if err := json.Unmarshal(raw, &value); err != nil {
+			continue
+		}
+		if d.Extra == nil {
+			d.Extra = make(map[string]bool)
+		}
+		d.Extra[key] = value
+	}
+
+	return nil
+}
+
 // SettingsLogs represents the settings logs of a profile.
 type SettingsLogs struct {
 	Enabled   bool              `json:"enabled"`
@@ -23,6 +90,15 @@ type SettingsLogs struct {
 	Location  string            `json:"location,omitempty"`
 }
 
+// MeetsRetentionPolicy reports whether the logs are kept for at most maxDays days. Disabled logs,
+// or logs without a retention period, always meet the policy.
+func (l *SettingsLogs) MeetsRetentionPolicy(maxDays int) bool {
+	if !l.Enabled || l.Retention == 0 {
+		return true
+	}
+	return l.Retention <= maxDays*24*60*60
+}
+
 // GetSettingsLogsRequest encapsulates the request for getting the settings logs of a profile.
 type GetSettingsLogsRequest struct {
 	ProfileID string
@@ -47,7 +123,7 @@ type settingsLogsResponse struct {
 
 // settingsLogsService represents the NextDNS settings logs service.
 type settingsLogsService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ SettingsLogsService = &settingsLogsService{}
@@ -56,7 +132,7 @@ var _ SettingsLogsService = &settingsLogsService{}
 // nolint: revive
 func NewSettingsLogsService(client *Client) *settingsLogsService {
 	return &settingsLogsService{
-		client: client,
+		client: newServiceClient(client, ServiceSettingsLogs),
 	}
 }
 
@@ -77,19 +153,34 @@ func (s *settingsLogsService) Get(ctx context.Context, request *GetSettingsLogsR
 	return response.SettingsLogs, nil
 }
 
-// Update updates the settings logs of a profile.
+// Update updates the settings logs of a profile. The retention period is checked against the
+// supported ones (see the LogsRetention constants) before making the request.
 func (s *settingsLogsService) Update(ctx context.Context, request *UpdateSettingsLogsRequest) error {
+	if err := validateLogsRetention(request.SettingsLogs); err != nil {
+		return fmt.Errorf("error validating request to update the logs settings: %w", err)
+	}
+
 	path := fmt.Sprintf("%s/%s", profileAPIPath(request.ProfileID), settingsLogsAPIPath)
 	req, err := s.client.newRequest(http.MethodPatch, path, request.SettingsLogs)
 	if err != nil {
 		return fmt.Errorf("error creating request to update the logs settings: %w", err)
 	}
 
-	response := settingsLogsResponse{}
-	err = s.client.do(ctx, req, &response)
+	err = s.client.do(ctx, req, nil)
 	if err != nil {
 		return fmt.Errorf("error making a request to update the logs settings: %w", err)
 	}
 
 	return nil
 }
+
+// validateLogsRetention checks the retention period of the logs settings is unset or supported.
+func validateLogsRetention(logs *SettingsLogs) error {
+	if logs == nil || logs.Retention == 0 || slices.Contains(logsRetentions, logs.Retention) {
+		return nil
+	}
+	return &Error{
+		Type:    ErrorTypeRequest,
+		Message: fmt.Sprintf("unsupported logs retention %d: must be one of the LogsRetention periods, in seconds", logs.Retention),
+	}
+}
@@ -0,0 +1,97 @@
+package nextdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSettingsLogsDropMinimal(t *testing.T) {
+	c := is.New(t)
+
+	var logs SettingsLogs
+	err := json.Unmarshal([]byte(`{"enabled": true, "drop": {"ip": true, "domain": false}, "retention": 7776000, "location": "eu"}`), &logs)
+	c.NoErr(err)
+	c.Equal(logs.Drop, &SettingsLogsDrop{IP: true})
+
+	out, err := json.Marshal(logs.Drop)
+	c.NoErr(err)
+	c.Equal(string(out), `{"domain":false,"ip":true}`)
+}
+
+func TestSettingsLogsDropExtended(t *testing.T) {
+	c := is.New(t)
+
+	var drop SettingsLogsDrop
+	err := json.Unmarshal([]byte(`{"ip": false, "domain": true, "device": true, "raw": false}`), &drop)
+	c.NoErr(err)
+	c.Equal(drop.Domain, true)
+	c.Equal(drop.Extra, map[string]bool{"device": true, "raw": false})
+
+	out, err := json.Marshal(&SettingsLogs{Enabled: true, Drop: &drop})
+	c.NoErr(err)
+	c.Equal(string(out), `{"enabled":true,"drop":{"device":true,"domain":true,"ip":false,"raw":false}}`)
+}
+
+func TestSettingsLogsMeetsRetentionPolicy(t *testing.T) {
+	c := is.New(t)
+
+	c.True((&SettingsLogs{Enabled: true, Retention: 2592000}).MeetsRetentionPolicy(30))
+	c.True(!(&SettingsLogs{Enabled: true, Retention: 7776000}).MeetsRetentionPolicy(30))
+	c.True((&SettingsLogs{Enabled: false, Retention: 7776000}).MeetsRetentionPolicy(30))
+	c.True((&SettingsLogs{Enabled: true}).MeetsRetentionPolicy(1))
+}
+
+func TestSettingsLogsUpdateRetention(t *testing.T) {
+	c := is.New(t)
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PATCH")
+		c.Equal(r.URL.Path, "/profiles/abc123/settings/logs")
+		calls++
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	tests := []struct {
+		retention int
+		valid     bool
+	}{
+		{retention: 0, valid: true},
+		{retention: LogsRetention1Hour, valid: true},
+		{retention: LogsRetention7Days, valid: true},
+		{retention: LogsRetention2Years, valid: true},
+		{retention: 1, valid: false},
+		{retention: 48 * 3600, valid: false},
+		{retention: -LogsRetention1Day, valid: false},
+	}
+
+	for _, tt := range tests {
+		calls = 0
+		err := client.SettingsLogs.Update(context.Background(), &UpdateSettingsLogsRequest{
+			ProfileID:    "abc123",
+			SettingsLogs: &SettingsLogs{Enabled: true, Retention: tt.retention},
+		})
+
+		if tt.valid {
+			c.NoErr(err)
+			c.Equal(calls, 1)
+			continue
+		}
+
+		var e *Error
+		c.True(errors.As(err, &e))
+		c.Equal(e.Type, ErrorTypeRequest)
+		c.Equal(calls, 0)
+	}
+}
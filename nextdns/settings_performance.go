@@ -10,6 +10,10 @@ import (
 const settingsPerformanceAPIPath = "settings/performance"
 
 // SettingsPerformance represents the settings performance of a profile.
+//
+// Ecs enables EDNS Client Subnet, which forwards a truncated portion of the client IP
+// address to upstream authoritative servers to improve geo-routing of CDNs. It trades
+// some privacy for performance, so it should only be enabled deliberately.
 type SettingsPerformance struct {
 	Ecs             bool `json:"ecs"`
 	CacheBoost      bool `json:"cacheBoost"`
@@ -22,9 +26,23 @@ type GetSettingsPerformanceRequest struct {
 }
 
 // UpdateSettingsPerformanceRequest encapsulates the request for updating the settings performance of a profile.
+// To patch a single setting, leave SettingsPerformance nil and set only the pointer fields to
+// change: only the non-nil ones are sent, so the others are left untouched. When
+// SettingsPerformance is set, it's sent as is and every setting is replaced.
 type UpdateSettingsPerformanceRequest struct {
 	ProfileID           string
 	SettingsPerformance *SettingsPerformance
+	Ecs                 *bool
+	CacheBoost          *bool
+	CnameFlattening     *bool
+}
+
+// PrivacyImpact returns a short description of the privacy implications of the performance settings.
+func (s *SettingsPerformance) PrivacyImpact() string {
+	if s != nil && s.Ecs {
+		return "EDNS Client Subnet is enabled: part of the client IP address is shared with upstream DNS servers"
+	}
+	return "EDNS Client Subnet is disabled: the client IP address is not shared with upstream DNS servers"
 }
 
 // SettingsPerformanceService is an interface for communicating with the NextDNS settings performance API endpoint.
@@ -40,7 +58,7 @@ type settingsPerformanceResponse struct {
 
 // settingsPerformanceService represents the NextDNS settings performance service.
 type settingsPerformanceService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ SettingsPerformanceService = &settingsPerformanceService{}
@@ -49,7 +67,7 @@ var _ SettingsPerformanceService = &settingsPerformanceService{}
 // nolint: revive
 func NewSettingsPerformanceService(client *Client) *settingsPerformanceService {
 	return &settingsPerformanceService{
-		client: client,
+		client: newServiceClient(client, ServiceSettingsPerformance),
 	}
 }
 
@@ -73,13 +91,24 @@ func (s *settingsPerformanceService) Get(ctx context.Context, request *GetSettin
 // Update updates the performance settings of a profile.
 func (s *settingsPerformanceService) Update(ctx context.Context, request *UpdateSettingsPerformanceRequest) error {
 	path := fmt.Sprintf("%s/%s", profileAPIPath(request.ProfileID), settingsPerformanceAPIPath)
-	req, err := s.client.newRequest(http.MethodPatch, path, request.SettingsPerformance)
+	var body interface{} = request.SettingsPerformance
+	if request.SettingsPerformance == nil {
+		body = struct {
+			Ecs             *bool `json:"ecs,omitempty"`
+			CacheBoost      *bool `json:"cacheBoost,omitempty"`
+			CnameFlattening *bool `json:"cnameFlattening,omitempty"`
+		}{
+			Ecs:             request.Ecs,
+			CacheBoost:      request.CacheBoost,
+			CnameFlattening: request.CnameFlattening,
+		}
+	}
+	req, err := s.client.newRequest(http.MethodPatch, path, body)
 	if err != nil {
 		return fmt.Errorf("error creating request to update the performance settings: %w", err)
 	}
 
-	response := settingsPerformanceResponse{}
-	err = s.client.do(ctx, req, &response)
+	err = s.client.do(ctx, req, nil)
 	if err != nil {
 		return fmt.Errorf("error making a request to update the performance settings: %w", err)
 	}
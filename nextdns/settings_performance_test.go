@@ -0,0 +1,46 @@
+package nextdns
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSettingsPerformanceUpdateOnlyCacheBoost(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PATCH")
+		c.Equal(r.URL.Path, "/profiles/abc123/settings/performance")
+
+		body, err := io.ReadAll(r.Body)
+		c.NoErr(err)
+		c.Equal(string(body), "{\"cacheBoost\":true}\n")
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+	enabled := true
+	err = client.SettingsPerformance.Update(ctx, &UpdateSettingsPerformanceRequest{
+		ProfileID:  "abc123",
+		CacheBoost: &enabled,
+	})
+
+	c.NoErr(err)
+}
+
+func TestSettingsPerformancePrivacyImpact(t *testing.T) {
+	c := is.New(t)
+
+	c.Equal((&SettingsPerformance{Ecs: true}).PrivacyImpact(), "EDNS Client Subnet is enabled: part of the client IP address is shared with upstream DNS servers")
+	c.Equal((&SettingsPerformance{CacheBoost: true}).PrivacyImpact(), "EDNS Client Subnet is disabled: the client IP address is not shared with upstream DNS servers")
+}
@@ -34,7 +34,7 @@ type setupResponse struct {
 
 // setupService represents the NextDNS setup service.
 type setupService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ SetupService = &setupService{}
@@ -43,7 +43,7 @@ var _ SetupService = &setupService{}
 // nolint: revive
 func NewSetupService(client *Client) *setupService {
 	return &setupService{
-		client: client,
+		client: newServiceClient(client, ServiceSetup),
 	}
 }
 
@@ -4,17 +4,19 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/netip"
 )
 
 // setupLinkedIPAPIPath is the HTTP path for the setup linked IP API.
 const setupLinkedIPAPIPath = "setup/linkedip"
 
 // SetupLinkedIP represents the linked IP configuration settings for a NextDNS profile.
+// The empty fields are left out of the updates, so only the fields set are changed.
 type SetupLinkedIP struct {
-	Servers     []string `json:"servers"`
-	IP          string   `json:"ip"`
-	Ddns        string   `json:"ddns"`
-	UpdateToken string   `json:"updateToken"`
+	Servers     []string `json:"servers,omitempty"`
+	IP          string   `json:"ip,omitempty"`
+	Ddns        string   `json:"ddns,omitempty"`
+	UpdateToken string   `json:"updateToken,omitempty"`
 }
 
 // GetSetupLinkedIPRequest encapsulates the request for getting the setup linked ip settings of a profile.
@@ -32,6 +34,7 @@ type UpdateSetupLinkedIPRequest struct {
 type SetupLinkedIPService interface {
 	Get(context.Context, *GetSetupLinkedIPRequest) (*SetupLinkedIP, error)
 	Update(context.Context, *UpdateSetupLinkedIPRequest) error
+	NeedsUpdate(ctx context.Context, profileID string, currentPublicIP string) (bool, error)
 }
 
 // SetupLinkedIPResponse represents the setup linked ip response.
@@ -41,7 +44,7 @@ type setupLinkedIPResponse struct {
 
 // SetupLinkedIPService represents the NextDNS setup linked ip service.
 type setupLinkedIPService struct {
-	client *Client
+	client *serviceClient
 }
 
 var _ SetupLinkedIPService = &setupLinkedIPService{}
@@ -50,7 +53,7 @@ var _ SetupLinkedIPService = &setupLinkedIPService{}
 // nolint: revive
 func NewSetupLinkedIPService(client *Client) *setupLinkedIPService {
 	return &setupLinkedIPService{
-		client: client,
+		client: newServiceClient(client, ServiceSetupLinkedIP),
 	}
 }
 
@@ -71,7 +74,7 @@ func (s *setupLinkedIPService) Get(ctx context.Context, request *GetSetupLinkedI
 	return response.SetupLinkedIP, nil
 }
 
-// Update updates the setup linked ip of a profile.
+// Update updates the setup linked ip of a profile, e.g. its DDNS hostname or its update token.
 func (s *setupLinkedIPService) Update(ctx context.Context, request *UpdateSetupLinkedIPRequest) error {
 	path := fmt.Sprintf("%s/%s", profileAPIPath(request.ProfileID), setupLinkedIPAPIPath)
 	req, err := s.client.newRequest(http.MethodPatch, path, request.SetupLinkedIP)
@@ -86,3 +89,28 @@ func (s *setupLinkedIPService) Update(ctx context.Context, request *UpdateSetupL
 
 	return nil
 }
+
+// NeedsUpdate reports whether the linked IP of a profile differs from the current public IP, so
+// DDNS tools can skip the unnecessary updates. The IPs are compared as addresses, not as strings,
+// and a profile without a linked IP always needs an update.
+func (s *setupLinkedIPService) NeedsUpdate(ctx context.Context, profileID string, currentPublicIP string) (bool, error) {
+	current, err := netip.ParseAddr(currentPublicIP)
+	if err != nil {
+		return false, fmt.Errorf("error parsing the current public ip: %w", err)
+	}
+
+	linkedIP, err := s.Get(ctx, &GetSetupLinkedIPRequest{ProfileID: profileID})
+	if err != nil {
+		return false, err
+	}
+	if linkedIP == nil || linkedIP.IP == "" {
+		return true, nil
+	}
+
+	linked, err := netip.ParseAddr(linkedIP.IP)
+	if err != nil {
+		return true, nil
+	}
+
+	return linked.Unmap() != current.Unmap(), nil
+}
@@ -2,6 +2,7 @@ package nextdns
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -55,23 +56,65 @@ func TestSetupLinkedIpGet(t *testing.T) {
 func TestSetupLinkedIpUpdate(t *testing.T) {
 	c := is.New(t)
 
-	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusNoContent)
-		_, err := w.Write([]byte(""))
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "PATCH")
+		c.Equal(r.URL.Path, "/profiles/abc123/setup/linkedip")
+
+		body, err := io.ReadAll(r.Body)
 		c.NoErr(err)
+		c.Equal(string(body), "{\"servers\":[\"1.1.1.1\"],\"ddns\":\"foobar.no-ip.org\",\"updateToken\":\"token123\"}\n")
+
+		w.WriteHeader(http.StatusNoContent)
 	}))
+	defer ts.Close()
 
-	client, err := New(WithBaseURL(ts.URL), WithDebug())
+	client, err := New(WithBaseURL(ts.URL))
 	c.NoErr(err)
 
 	ctx := context.Background()
 	request := &UpdateSetupLinkedIPRequest{
 		ProfileID: "abc123",
 		SetupLinkedIP: &SetupLinkedIP{
-			Ddns: "foobar.no-ip.org",
+			Servers:     []string{"1.1.1.1"},
+			Ddns:        "foobar.no-ip.org",
+			UpdateToken: "token123",
 		},
 	}
 	err = client.SetupLinkedIP.Update(ctx, request)
 
 	c.NoErr(err)
 }
+
+func TestSetupLinkedIPNeedsUpdate(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/setup/linkedip")
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": {"servers": ["1.1.1.1"], "ip": "1.2.3.4", "ddns": null, "updateToken": "foobar"}}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	ctx := context.Background()
+
+	needsUpdate, err := client.SetupLinkedIP.NeedsUpdate(ctx, "abc123", "1.2.3.4")
+	c.NoErr(err)
+	c.Equal(needsUpdate, false)
+
+	needsUpdate, err = client.SetupLinkedIP.NeedsUpdate(ctx, "abc123", "::ffff:1.2.3.4")
+	c.NoErr(err)
+	c.Equal(needsUpdate, false)
+
+	needsUpdate, err = client.SetupLinkedIP.NeedsUpdate(ctx, "abc123", "5.6.7.8")
+	c.NoErr(err)
+	c.Equal(needsUpdate, true)
+
+	_, err = client.SetupLinkedIP.NeedsUpdate(ctx, "abc123", "not an ip")
+	c.True(err != nil)
+}
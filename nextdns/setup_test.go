@@ -12,7 +12,10 @@ import (
 func TestSetupGet(t *testing.T) {
 	c := is.New(t)
 
-	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Equal(r.Method, "GET")
+		c.Equal(r.URL.Path, "/profiles/abc123/setup")
+
 		w.WriteHeader(http.StatusOK)
 		out := `
 {
@@ -39,6 +42,7 @@ func TestSetupGet(t *testing.T) {
 		_, err := w.Write([]byte(out))
 		c.NoErr(err)
 	}))
+	defer ts.Close()
 
 	client, err := New(WithBaseURL(ts.URL))
 	c.NoErr(err)
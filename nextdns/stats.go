@@ -0,0 +1,71 @@
+package nextdns
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// statsWindow is the number of recent requests whose latency is kept to compute the percentiles.
+const statsWindow = 256
+
+// Stats represents the statistics of the requests sent by a client.
+type Stats struct {
+	Requests int64         // Number of requests sent.
+	Errors   int64         // Number of requests failing with a network error or a 4xx/5xx response.
+	P50      time.Duration // Median latency of the recent requests.
+	P95      time.Duration // 95th percentile latency of the recent requests.
+}
+
+// stats records the requests sent by a client. The latencies of the recent requests are kept in a
+// ring buffer. It's shared by all the services of a client.
+type stats struct {
+	mu        sync.Mutex
+	requests  int64
+	errors    int64
+	latencies [statsWindow]time.Duration
+	next      int
+}
+
+// record records a request that took the given latency.
+func (s *stats) record(latency time.Duration, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	if failed {
+		s.errors++
+	}
+	s.latencies[s.next] = latency
+	s.next = (s.next + 1) % statsWindow
+}
+
+// Stats returns the number of requests sent by the client, and the latency percentiles of the
+// last requests. The percentiles are zero until a request is sent.
+func (c *Client) Stats() Stats {
+	c.stats.mu.Lock()
+	defer c.stats.mu.Unlock()
+
+	stats := Stats{
+		Requests: c.stats.requests,
+		Errors:   c.stats.errors,
+	}
+
+	count := int(min(c.stats.requests, statsWindow))
+	if count == 0 {
+		return stats
+	}
+
+	latencies := slices.Clone(c.stats.latencies[:count])
+	slices.Sort(latencies)
+	stats.P50 = latencies[percentileIndex(count, 50)]
+	stats.P95 = latencies[percentileIndex(count, 95)]
+	return stats
+}
+
+// percentileIndex returns the index of the percentile p in a sorted slice of count elements,
+// using the nearest-rank method.
+func percentileIndex(count int, p int) int {
+	rank := (p*count + 99) / 100
+	return max(rank-1, 0)
+}
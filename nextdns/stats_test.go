@@ -0,0 +1,71 @@
+package nextdns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestClientStats(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/profiles/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"errors": [{"code": "notFound"}]}`))
+			c.NoErr(err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"data": []}`))
+		c.NoErr(err)
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+	c.Equal(client.Stats(), Stats{})
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Profiles.List(ctx, &ListProfileRequest{})
+			c.NoErr(err)
+		}()
+	}
+	wg.Wait()
+
+	_, err = client.Profiles.Get(ctx, &GetProfileRequest{ProfileID: "missing"})
+	c.True(IsNotFound(err))
+
+	stats := client.Stats()
+	c.Equal(stats.Requests, int64(11))
+	c.Equal(stats.Errors, int64(1))
+	c.True(stats.P50 > 0)
+	c.True(stats.P95 >= stats.P50)
+}
+
+func TestStatsPercentiles(t *testing.T) {
+	c := is.New(t)
+
+	client, err := New()
+	c.NoErr(err)
+
+	// The ring buffer only keeps the latencies of the last requests.
+	for i := 1; i <= statsWindow+100; i++ {
+		client.stats.record(time.Duration(i)*time.Millisecond, false)
+	}
+
+	stats := client.Stats()
+	c.Equal(stats.Requests, int64(statsWindow+100))
+	c.Equal(stats.P50, 228*time.Millisecond)
+	c.Equal(stats.P95, 344*time.Millisecond)
+}
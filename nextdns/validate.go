@@ -0,0 +1,244 @@
+package nextdns
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// maxProfileNameLength is the maximum length of a profile name.
+const maxProfileNameLength = 100
+
+// logsRetentions are the supported logs retention periods, in seconds.
+var logsRetentions = []int{
+	LogsRetention1Hour,
+	LogsRetention6Hours,
+	LogsRetention1Day,
+	LogsRetention7Days,
+	LogsRetention30Days,
+	LogsRetention90Days,
+	LogsRetention180Days,
+	LogsRetention1Year,
+	LogsRetention2Years,
+}
+
+// logsLocations are the supported logs storage locations.
+var logsLocations = []string{"us", "eu", "gb", "ch"}
+
+// recreationTimeRegexp matches a recreation time in the "HH:MM" or "HH:MM:SS" format.
+var recreationTimeRegexp = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9](:[0-5][0-9])?$`)
+
+// domainRegexp matches a domain name, optionally prefixed by a wildcard.
+var domainRegexp = regexp.MustCompile(`^(\*\.)?([a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9_])?\.)*[a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9_])?$`)
+
+// tldRegexp matches a lowercase top-level domain without its leading dot, in its ASCII or
+// punycode form (e.g. "ru", "xn--p1ai").
+var tldRegexp = regexp.MustCompile(`^([a-z]{2,63}|xn--[a-z0-9-]{1,59})$`)
+
+// ValidationError represents a request field rejected by the client-side validation.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+// Error returns the string representation of the validation error.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Message)
+}
+
+// Validate checks the whole request before sending it and returns all the violations found,
+// joined in a single error. Each violation is a *ValidationError.
+func (r *CreateProfileRequest) Validate() error {
+	var errs []error
+
+	if len(r.Name) > maxProfileNameLength {
+		errs = append(errs, &ValidationError{Field: "name", Message: fmt.Sprintf("must be at most %d characters long", maxProfileNameLength)})
+	}
+	if r.Security != nil {
+		errs = append(errs, validateSecurity(r.Security)...)
+	}
+	if r.ParentalControl != nil {
+		errs = append(errs, validateParentalControl(r.ParentalControl)...)
+	}
+	for i, entry := range r.Denylist {
+		field := fmt.Sprintf("denylist[%d]", i)
+		if entry == nil {
+			errs = append(errs, &ValidationError{Field: field, Message: "must not be nil"})
+			continue
+		}
+		errs = append(errs, validateDomain(field+".id", entry.ID)...)
+	}
+	for i, entry := range r.Allowlist {
+		field := fmt.Sprintf("allowlist[%d]", i)
+		if entry == nil {
+			errs = append(errs, &ValidationError{Field: field, Message: "must not be nil"})
+			continue
+		}
+		errs = append(errs, validateDomain(field+".id", entry.ID)...)
+	}
+	if r.Settings != nil && r.Settings.Logs != nil {
+		errs = append(errs, validateSettingsLogs(r.Settings.Logs)...)
+	}
+	for i, rewrite := range r.Rewrites {
+		errs = append(errs, validateRewrite(fmt.Sprintf("rewrites[%d]", i), rewrite)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateSecurity validates the security settings.
+func validateSecurity(security *Security) []error {
+	var errs []error
+	for i, tld := range security.Tlds {
+		field := fmt.Sprintf("security.tlds[%d]", i)
+		if tld == nil {
+			errs = append(errs, &ValidationError{Field: field, Message: "must not be nil"})
+			continue
+		}
+		errs = append(errs, validateTld(field+".id", tld.ID)...)
+	}
+	return errs
+}
+
+// validateSecurityTlds validates a list of security TLDs, as sent by SecurityTlds.Create.
+func validateSecurityTlds(tlds []*SecurityTlds) []error {
+	var errs []error
+	for i, tld := range tlds {
+		field := fmt.Sprintf("securityTlds[%d]", i)
+		if tld == nil {
+			errs = append(errs, &ValidationError{Field: field, Message: "must not be nil"})
+			continue
+		}
+		errs = append(errs, validateTld(field+".id", tld.ID)...)
+	}
+	return errs
+}
+
+// validateTld validates a security TLD, which is given lowercase and without its leading dot.
+func validateTld(field string, tld string) []error {
+	switch {
+	case tld == "":
+		return []error{&ValidationError{Field: field, Message: "must not be empty"}}
+	case strings.HasPrefix(tld, "."):
+		return []error{&ValidationError{Field: field, Message: fmt.Sprintf("%q must not have a leading dot", tld)}}
+	case tld != strings.ToLower(tld):
+		return []error{&ValidationError{Field: field, Message: fmt.Sprintf("%q must be lowercase", tld)}}
+	case !tldRegexp.MatchString(tld):
+		return []error{&ValidationError{Field: field, Message: fmt.Sprintf("%q is not a valid TLD", tld)}}
+	}
+	return nil
+}
+
+// validateParentalControl validates the parental control recreation times.
+func validateParentalControl(parentalControl *ParentalControl) []error {
+	if parentalControl.Recreation == nil || parentalControl.Recreation.Times == nil {
+		return nil
+	}
+
+	times := parentalControl.Recreation.Times
+	days := []struct {
+		name     string
+		interval *ParentalControlRecreationInterval
+	}{
+		{"monday", times.Monday},
+		{"tuesday", times.Tuesday},
+		{"wednesday", times.Wednesday},
+		{"thursday", times.Thursday},
+		{"friday", times.Friday},
+		{"saturday", times.Saturday},
+		{"sunday", times.Sunday},
+	}
+
+	var errs []error
+	for _, day := range days {
+		if day.interval == nil {
+			continue
+		}
+		field := "parentalControl.recreation.times." + day.name
+		if !recreationTimeRegexp.MatchString(day.interval.Start) {
+			errs = append(errs, &ValidationError{Field: field + ".start", Message: fmt.Sprintf("%q must be in the HH:MM format", day.interval.Start)})
+		}
+		if !recreationTimeRegexp.MatchString(day.interval.End) {
+			errs = append(errs, &ValidationError{Field: field + ".end", Message: fmt.Sprintf("%q must be in the HH:MM format", day.interval.End)})
+		}
+	}
+	return errs
+}
+
+// validateSettingsLogs validates the logs retention and location.
+func validateSettingsLogs(logs *SettingsLogs) []error {
+	var errs []error
+	if logs.Retention != 0 && !slices.Contains(logsRetentions, logs.Retention) {
+		errs = append(errs, &ValidationError{Field: "settings.logs.retention", Message: fmt.Sprintf("%d is not a supported retention period", logs.Retention)})
+	}
+	if logs.Location != "" && !slices.Contains(logsLocations, logs.Location) {
+		errs = append(errs, &ValidationError{Field: "settings.logs.location", Message: fmt.Sprintf("%q must be one of %s", logs.Location, strings.Join(logsLocations, ", "))})
+	}
+	return errs
+}
+
+// validateRewrite validates a rewrite record.
+func validateRewrite(field string, rewrite *Rewrites) []error {
+	if rewrite == nil {
+		return []error{&ValidationError{Field: field, Message: "must not be nil"}}
+	}
+
+	var errs []error
+	if rewrite.Name == "" {
+		errs = append(errs, &ValidationError{Field: field + ".name", Message: "must not be empty"})
+	}
+	if rewrite.Content == "" {
+		errs = append(errs, &ValidationError{Field: field + ".content", Message: "must not be empty"})
+	}
+	return append(errs, validateRewriteContent(field, rewrite.Type, rewrite.Content)...)
+}
+
+// validateRewriteContent validates the type of a rewrite record, if set, and its content against
+// the type, if both are set.
+func validateRewriteContent(field string, recordType string, content string) []error {
+	if content == "" {
+		return validateRewriteType(field+".type", recordType)
+	}
+
+	switch recordType {
+	case "":
+		// The API infers the type from the content.
+	case RewriteTypeA:
+		if addr, err := netip.ParseAddr(content); err != nil || !addr.Is4() {
+			return []error{&ValidationError{Field: field + ".content", Message: fmt.Sprintf("%q is not a valid IPv4 address for an A record", content)}}
+		}
+	case RewriteTypeAAAA:
+		if addr, err := netip.ParseAddr(content); err != nil || !addr.Is6() {
+			return []error{&ValidationError{Field: field + ".content", Message: fmt.Sprintf("%q is not a valid IPv6 address for an AAAA record", content)}}
+		}
+	case RewriteTypeCNAME:
+		host := NormalizeDomain(content)
+		if strings.HasPrefix(host, "*.") || !domainRegexp.MatchString(host) {
+			return []error{&ValidationError{Field: field + ".content", Message: fmt.Sprintf("%q is not a valid hostname for a CNAME record", content)}}
+		}
+	default:
+		return validateRewriteType(field+".type", recordType)
+	}
+	return nil
+}
+
+// validateRewriteType checks the type of a rewrite record is unset or supported.
+func validateRewriteType(field string, recordType string) []error {
+	switch recordType {
+	case "", RewriteTypeA, RewriteTypeAAAA, RewriteTypeCNAME:
+		return nil
+	default:
+		return []error{&ValidationError{Field: field, Message: fmt.Sprintf("%q is not supported: must be %q, %q or %q", recordType, RewriteTypeA, RewriteTypeAAAA, RewriteTypeCNAME)}}
+	}
+}
+
+// validateDomain validates a denylist or allowlist domain, wildcards included.
+func validateDomain(field string, domain string) []error {
+	if !domainRegexp.MatchString(NormalizeDomain(domain)) {
+		return []error{&ValidationError{Field: field, Message: fmt.Sprintf("%q is not a valid domain", domain)}}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package nextdns
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCreateProfileRequestValidate(t *testing.T) {
+	c := is.New(t)
+
+	request := &CreateProfileRequest{
+		Name: "valid",
+		Security: &Security{
+			Tlds: []*SecurityTlds{{ID: "xyz"}},
+		},
+		ParentalControl: &ParentalControl{
+			Recreation: &ParentalControlRecreation{
+				Times: &ParentalControlRecreationTimes{
+					Monday: &ParentalControlRecreationInterval{Start: "18:00", End: "20:30:00"},
+				},
+				Timezone: "Europe/Paris",
+			},
+		},
+		Denylist:  []*Denylist{{ID: "ads.com", Active: true}, {ID: "*.tracker.net", Active: true}},
+		Allowlist: []*Allowlist{{ID: "duckduckgo.com", Active: true}},
+		Settings: &Settings{
+			Logs: &SettingsLogs{Enabled: true, Retention: 7776000, Location: "eu"},
+		},
+		Rewrites: []*Rewrites{{Name: "router.lan", Content: "192.168.1.1"}},
+	}
+
+	c.NoErr(request.Validate())
+	c.NoErr((&CreateProfileRequest{}).Validate())
+}
+
+func TestCreateProfileRequestValidateViolations(t *testing.T) {
+	c := is.New(t)
+
+	request := &CreateProfileRequest{
+		Name: strings.Repeat("a", 101),
+		Security: &Security{
+			Tlds: []*SecurityTlds{{ID: ""}, nil},
+		},
+		ParentalControl: &ParentalControl{
+			Recreation: &ParentalControlRecreation{
+				Times: &ParentalControlRecreationTimes{
+					Friday: &ParentalControlRecreationInterval{Start: "25:00", End: "20:00"},
+				},
+			},
+		},
+		Denylist:  []*Denylist{{ID: "not a domain"}},
+		Allowlist: []*Allowlist{nil},
+		Settings: &Settings{
+			Logs: &SettingsLogs{Retention: 42, Location: "mars"},
+		},
+		Rewrites: []*Rewrites{{Name: "router.lan"}},
+	}
+
+	err := request.Validate()
+	c.True(err != nil)
+
+	var joined interface{ Unwrap() []error }
+	c.True(errors.As(err, &joined))
+
+	var fields []string
+	for _, e := range joined.Unwrap() {
+		var validationErr *ValidationError
+		c.True(errors.As(e, &validationErr))
+		fields = append(fields, validationErr.Field)
+	}
+
+	c.Equal(fields, []string{
+		"name",
+		"security.tlds[0].id",
+		"security.tlds[1]",
+		"parentalControl.recreation.times.friday.start",
+		"denylist[0].id",
+		"allowlist[0]",
+		"settings.logs.retention",
+		"settings.logs.location",
+		"rewrites[0].content",
+	})
+}
+
+func TestProfilesCreateValidateBeforeCreate(t *testing.T) {
+	c := is.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("no request expected for an invalid profile")
+	}))
+	defer ts.Close()
+
+	client, err := New(WithBaseURL(ts.URL))
+	c.NoErr(err)
+
+	_, err = client.Profiles.Create(context.Background(), &CreateProfileRequest{
+		Name:                 "invalid",
+		Settings:             &Settings{Logs: &SettingsLogs{Location: "mars"}},
+		ValidateBeforeCreate: true,
+	})
+
+	var validationErr *ValidationError
+	c.True(errors.As(err, &validationErr))
+	c.Equal(validationErr.Field, "settings.logs.location")
+}